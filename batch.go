@@ -0,0 +1,228 @@
+package prismata
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const defaultConcurrency = 4
+
+// FetchOptions configures a batch download started by FetchAll.
+type FetchOptions struct {
+	// Client is used to download each replay. Defaults to a zero-valued Client.
+	Client *Client
+	// Concurrency is the maximum number of replays downloaded at once.
+	// Defaults to 4.
+	Concurrency int
+	// Interval is the minimum time between the start of two downloads from the
+	// same host. Zero disables rate limiting.
+	Interval time.Duration
+}
+
+// FetchResult is the outcome of downloading a single replay in a batch.
+type FetchResult struct {
+	Code   string
+	Replay *Replay
+	Err    error
+	// Bytes is the number of response body bytes read for this replay.
+	Bytes int64
+}
+
+// FetchStats summarizes a completed batch download.
+type FetchStats struct {
+	Succeeded int
+	Failed    int
+	Bytes     int64
+	// Dropped is the number of results not delivered because the context was
+	// done before they were received. Dropped results are also counted as
+	// succeeded or failed.
+	Dropped int
+}
+
+// Batch is a batch download in progress.
+type Batch struct {
+	results chan FetchResult
+	done    chan struct{}
+	stats   FetchStats
+}
+
+// FetchAll downloads the replays of the provided codes concurrently. Codes are
+// normalized with ParseCode and duplicates are downloaded once. Results are
+// delivered through the Results channel in completion order as they become
+// available; a failed download does not affect the others. Once the provided
+// context is done, the remaining codes are reported with the context's error,
+// and results that are not received are dropped so that the batch completes
+// even if the caller stops reading.
+func FetchAll(ctx context.Context, codes []string, opts *FetchOptions) *Batch {
+	if opts == nil {
+		opts = &FetchOptions{}
+	}
+
+	b := &Batch{
+		results: make(chan FetchResult),
+		done:    make(chan struct{}),
+	}
+	go b.run(ctx, dedupe(codes), opts)
+
+	return b
+}
+
+// Results returns the channel on which the result for each code is delivered.
+// The channel is closed once every code has been processed. Until the context
+// is done, the channel must be drained for the batch to complete.
+func (b *Batch) Results() <-chan FetchResult {
+	return b.results
+}
+
+// Stats waits for the batch to complete and returns its aggregate statistics.
+// Unless the context is done, Stats must not be called before the Results
+// channel has been drained.
+func (b *Batch) Stats() FetchStats {
+	<-b.done
+	return b.stats
+}
+
+func (b *Batch) run(ctx context.Context, codes []string, opts *FetchOptions) {
+	c := opts.Client
+	if c == nil {
+		c = defaultClient
+	}
+
+	n := opts.Concurrency
+	if n <= 0 {
+		n = defaultConcurrency
+	}
+
+	lim := &limiter{interval: opts.Interval, next: make(map[string]time.Time)}
+	jobs := make(chan string)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for code := range jobs {
+				res := fetchOne(ctx, c, lim, code)
+
+				mu.Lock()
+				if res.Err != nil {
+					b.stats.Failed++
+				} else {
+					b.stats.Succeeded++
+				}
+				b.stats.Bytes += res.Bytes
+				mu.Unlock()
+
+				if !b.send(ctx, res) {
+					mu.Lock()
+					b.stats.Dropped++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, code := range codes {
+		jobs <- code
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(b.results)
+	close(b.done)
+}
+
+// send delivers the result, giving up once the context is done and the result
+// has not been received. It reports whether the result was delivered.
+func (b *Batch) send(ctx context.Context, res FetchResult) bool {
+	select {
+	case b.results <- res:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// fetchOne downloads a single replay of a batch once the rate limiter allows.
+func fetchOne(ctx context.Context, c *Client, lim *limiter, code string) FetchResult {
+	res := FetchResult{Code: code}
+
+	if err := ctx.Err(); err != nil {
+		res.Err = err
+		return res
+	}
+
+	if _, err := ParseCode(code); err != nil {
+		res.Err = err
+		return res
+	}
+
+	if err := lim.wait(ctx, host(c.url(code))); err != nil {
+		res.Err = err
+		return res
+	}
+
+	res.Replay, res.Bytes, res.Err = c.fetch(ctx, code)
+	return res
+}
+
+// dedupe returns the provided codes without duplicates, preserving order.
+// Valid codes are normalized; invalid codes are kept as is so that their
+// error can be reported.
+func dedupe(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	out := make([]string, 0, len(codes))
+	for _, c := range codes {
+		if parsed, err := ParseCode(c); err == nil {
+			c = parsed.String()
+		}
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		out = append(out, c)
+	}
+
+	return out
+}
+
+// host returns the host of the provided URL, or the URL itself if it cannot
+// be parsed.
+func host(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	return u.Host
+}
+
+// limiter spaces out operations on the same host by a fixed interval.
+type limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// wait blocks until an operation on the provided host is allowed or the
+// context is done.
+func (l *limiter) wait(ctx context.Context, host string) error {
+	if l.interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	t := l.next[host]
+	if t.Before(now) {
+		t = now
+	}
+	l.next[host] = t.Add(l.interval)
+	l.mu.Unlock()
+
+	return sleep(ctx, t.Sub(now))
+}
@@ -0,0 +1,179 @@
+package prismata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFetchAll(t *testing.T) {
+	var mu sync.Mutex
+	var active, peak int
+	fs := http.FileServer(http.Dir("testdata"))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		if active > peak {
+			peak = active
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond * 10)
+		fs.ServeHTTP(w, r)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	var size int64
+	for _, code := range []string{replaycode1, replaycode2, replaycode3} {
+		fi, err := os.Stat("testdata/" + code + extension)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size += fi.Size()
+	}
+
+	codes := []string{replaycode1, replaycode2, replaycode1, replaycode3, "aaaaa-bbbbb"}
+	opts := &FetchOptions{
+		Client:      &Client{BaseURL: ts.URL, HTTPClient: ts.Client()},
+		Concurrency: 2,
+	}
+
+	b := FetchAll(context.Background(), codes, opts)
+	got := make(map[string]FetchResult)
+	for res := range b.Results() {
+		if _, ok := got[res.Code]; ok {
+			t.Errorf("got: <duplicate %v>, want: <unique>", res.Code)
+		}
+		got[res.Code] = res
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("got: <%v>, want: <%v>", len(got), 4)
+	}
+
+	for _, code := range []string{replaycode1, replaycode2, replaycode3} {
+		res := got[code]
+		if res.Err != nil {
+			t.Errorf("got: <%v>, want: <nil>", res.Err)
+			continue
+		}
+		if res.Replay.Code != code {
+			t.Errorf("got: <%v>, want: <%v>", res.Replay.Code, code)
+		}
+	}
+
+	if !errors.Is(got["aaaaa-bbbbb"].Err, ErrReplayNotFound) {
+		t.Errorf("got: <%v>, want: <%v>", got["aaaaa-bbbbb"].Err, ErrReplayNotFound)
+	}
+
+	exp := FetchStats{Succeeded: 3, Failed: 1, Bytes: size}
+	if s := b.Stats(); s != exp {
+		t.Errorf("got: <%+v>, want: <%+v>", s, exp)
+	}
+
+	if peak > opts.Concurrency {
+		t.Errorf("got: <%v>, want: <<= %v>", peak, opts.Concurrency)
+	}
+}
+
+func TestFetchAllInterval(t *testing.T) {
+	ts := testServer(nil)
+	defer ts.Close()
+
+	opts := &FetchOptions{
+		Client:      &Client{BaseURL: ts.URL, HTTPClient: ts.Client()},
+		Concurrency: 3,
+		Interval:    time.Millisecond * 30,
+	}
+
+	start := time.Now()
+	b := FetchAll(context.Background(), []string{replaycode1, replaycode2, replaycode3}, opts)
+	for range b.Results() {
+	}
+
+	if d := time.Since(start); d < opts.Interval*2 {
+		t.Errorf("got: <%v>, want: <>= %v>", d, opts.Interval*2)
+	}
+}
+
+func TestFetchAllCanceled(t *testing.T) {
+	ts := testServer(nil)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	opts := &FetchOptions{Client: &Client{BaseURL: ts.URL, HTTPClient: ts.Client()}}
+	b := FetchAll(ctx, []string{replaycode1, replaycode2, replaycode3}, opts)
+	received := 0
+	for res := range b.Results() {
+		received++
+		if !errors.Is(res.Err, context.Canceled) {
+			t.Errorf("got: <%v>, want: <%v>", res.Err, context.Canceled)
+		}
+	}
+
+	// Results may be dropped rather than delivered once the context is done.
+	s := b.Stats()
+	exp := FetchStats{Failed: 3, Dropped: 3 - received}
+	if s != exp {
+		t.Errorf("got: <%+v>, want: <%+v>", s, exp)
+	}
+}
+
+func TestFetchAllCanceledUnread(t *testing.T) {
+	ts := testServer(nil)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := &FetchOptions{Client: &Client{BaseURL: ts.URL, HTTPClient: ts.Client()}, Concurrency: 1}
+	b := FetchAll(ctx, []string{replaycode1, replaycode2, replaycode3}, opts)
+	cancel()
+
+	done := make(chan FetchStats)
+	go func() { done <- b.Stats() }()
+
+	select {
+	case s := <-done:
+		if s.Dropped != 3 {
+			t.Errorf("got: <%v>, want: <%v>", s.Dropped, 3)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("got: <blocked>, want: <completed batch>")
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	var cases = []struct {
+		name  string
+		codes []string
+		exp   []string
+	}{
+		{"Pass: no duplicates", []string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		{"Pass: duplicates", []string{"a", "b", "a", "c", "b"}, []string{"a", "b", "c"}},
+		{"Pass: empty", []string{}, []string{}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := dedupe(tt.codes)
+			if len(d) != len(tt.exp) {
+				t.Fatalf("got: <%v>, want: <%v>", d, tt.exp)
+			}
+			for i := range d {
+				if d[i] != tt.exp[i] {
+					t.Errorf("got: <%v>, want: <%v>", d, tt.exp)
+				}
+			}
+		})
+	}
+}
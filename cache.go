@@ -0,0 +1,234 @@
+package prismata
+
+import (
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw gzipped replays by replay code. Since replays never change
+// once recorded, entries never expire. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the stored replay of the provided code, if any.
+	Get(code string) ([]byte, bool, error)
+	// Put stores the replay of the provided code.
+	Put(code string, data []byte) error
+}
+
+// DiskCache is a Cache storing each replay as a file named after its code in
+// a single directory. When the total size of the stored replays exceeds the
+// limit, the least recently used replays are removed.
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	lru     *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+	size    int64
+}
+
+type cacheEntry struct {
+	code string
+	size int64
+}
+
+// NewDiskCache returns a DiskCache storing replays in the provided directory,
+// creating it if necessary. Replays already present in the directory are
+// kept and ordered by modification time. A maxSize of zero or less disables
+// eviction.
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	c := &DiskCache{
+		dir:     dir,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().After(infos[j].ModTime())
+	})
+
+	for _, fi := range infos {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), extension) {
+			continue
+		}
+		code := strings.TrimSuffix(fi.Name(), extension)
+		c.entries[code] = c.lru.PushBack(&cacheEntry{code: code, size: fi.Size()})
+		c.size += fi.Size()
+	}
+
+	// The cache is not shared yet, so evict can be called without c.mu.
+	if err := c.evict(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Get returns the stored replay of the provided code, if any, and marks it as
+// recently used.
+func (c *DiskCache) Get(code string) ([]byte, bool, error) {
+	path, err := c.path(code)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[code]
+	if !ok {
+		return nil, false, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		c.remove(e)
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.lru.MoveToFront(e)
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	return b, true, nil
+}
+
+// Put stores the replay of the provided code and evicts the least recently
+// used replays if the cache exceeds its maximum size.
+func (c *DiskCache) Put(code string, data []byte) error {
+	path, err := c.path(code)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := writeFile(path, data); err != nil {
+		return err
+	}
+
+	size := int64(len(data))
+	if e, ok := c.entries[code]; ok {
+		ce := e.Value.(*cacheEntry)
+		c.size += size - ce.size
+		ce.size = size
+		c.lru.MoveToFront(e)
+	} else {
+		c.entries[code] = c.lru.PushFront(&cacheEntry{code: code, size: size})
+		c.size += size
+	}
+
+	return c.evict()
+}
+
+// Seed stores every replay file found in the provided directory, such as a
+// directory of downloaded .json.gz files, and returns the number stored.
+func (c *DiskCache) Seed(dir string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+extension))
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, p := range paths {
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return n, err
+		}
+
+		code := strings.TrimSuffix(filepath.Base(p), extension)
+		if err := c.Put(code, b); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// Size returns the total size in bytes of the stored replays.
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// path returns the location of the file storing the replay of the provided
+// code.
+func (c *DiskCache) path(code string) (string, error) {
+	if code == "" || code == "." || code == ".." || strings.ContainsAny(code, `/\`) {
+		return "", fmt.Errorf("invalid cache key %q", code)
+	}
+
+	return filepath.Join(c.dir, code+extension), nil
+}
+
+// evict removes least recently used replays until the cache fits its maximum
+// size. The caller must hold c.mu.
+func (c *DiskCache) evict() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+
+	for c.size > c.maxSize && c.lru.Len() > 0 {
+		e := c.lru.Back()
+		err := os.Remove(filepath.Join(c.dir, e.Value.(*cacheEntry).code+extension))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		c.remove(e)
+	}
+
+	return nil
+}
+
+// remove forgets the provided entry. The caller must hold c.mu.
+func (c *DiskCache) remove(e *list.Element) {
+	ce := c.lru.Remove(e).(*cacheEntry)
+	delete(c.entries, ce.code)
+	c.size -= ce.size
+}
+
+// writeFile atomically replaces the file at path with data.
+func writeFile(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
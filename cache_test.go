@@ -0,0 +1,183 @@
+package prismata
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiskCacheSeed(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := c.Seed("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 3 {
+		t.Errorf("got: <%v>, want: <%v>", n, 3)
+	}
+
+	for _, code := range []string{replaycode1, replaycode2, replaycode3} {
+		exp, err := ioutil.ReadFile("testdata/" + code + extension)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, ok, err := c.Get(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ok {
+			t.Errorf("got: <%v>, want: <%v>", ok, true)
+		}
+
+		if !bytes.Equal(b, exp) {
+			t.Errorf("got: <%v bytes>, want: <%v bytes>", len(b), len(exp))
+		}
+	}
+}
+
+func TestDiskCacheEvict(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte{'x'}, 10)
+	for _, code := range []string{"a", "b", "c"} {
+		if err := c.Put(code, data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Using a makes b the least recently used entry.
+	if _, ok, _ := c.Get("a"); !ok {
+		t.Fatalf("got: <%v>, want: <%v>", ok, true)
+	}
+
+	if err := c.Put("d", data); err != nil {
+		t.Fatal(err)
+	}
+
+	var cases = []struct {
+		code string
+		exp  bool
+	}{
+		{"a", true},
+		{"b", false},
+		{"c", true},
+		{"d", true},
+	}
+
+	for _, tt := range cases {
+		t.Run("Pass: "+tt.code, func(t *testing.T) {
+			_, ok, err := c.Get(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ok != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", ok, tt.exp)
+			}
+		})
+	}
+
+	if c.Size() != 30 {
+		t.Errorf("got: <%v>, want: <%v>", c.Size(), 30)
+	}
+
+	re, err := NewDiskCache(dir, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if re.Size() != 30 {
+		t.Errorf("got: <%v>, want: <%v>", re.Size(), 30)
+	}
+}
+
+func TestDiskCacheInvalidKey(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, code := range []string{"", "..", "../escape", `a\b`} {
+		t.Run("Error: "+code, func(t *testing.T) {
+			err := c.Put(code, []byte("data"))
+			assertError(t, err, true)
+		})
+	}
+}
+
+func TestClientGetCache(t *testing.T) {
+	requests := 0
+	fs := http.FileServer(http.Dir("testdata"))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fs.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client(), Cache: cache}
+	for i := 0; i < 3; i++ {
+		r, err := c.Get(context.Background(), replaycode1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if r.Code != replaycode1 {
+			t.Errorf("got: <%v>, want: <%v>", r.Code, replaycode1)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("got: <%v>, want: <%v>", requests, 1)
+	}
+
+	if _, ok, _ := cache.Get(replaycode1); !ok {
+		t.Errorf("got: <%v>, want: <%v>", ok, true)
+	}
+}
+
+func TestClientGetCacheOffline(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cache.Seed("testdata"); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client(), Cache: cache}
+	for _, code := range []string{replaycode1, replaycode2, replaycode3} {
+		r, err := c.Get(context.Background(), code)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if r.Code != code {
+			t.Errorf("got: <%v>, want: <%v>", r.Code, code)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package prismata
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ChatInfo holds the raw chat section of a replay. None of the known replays
+// record any chat lines in it, so it is kept undecoded.
+type ChatInfo json.RawMessage
+
+// MarshalJSON returns the raw chat section, or an empty object if it is
+// missing.
+func (c ChatInfo) MarshalJSON() ([]byte, error) {
+	if len(c) == 0 {
+		return []byte("{}"), nil
+	}
+
+	return c, nil
+}
+
+// UnmarshalJSON keeps a copy of the raw chat section.
+func (c *ChatInfo) UnmarshalJSON(b []byte) error {
+	*c = append((*c)[:0], b...)
+	return nil
+}
+
+// ChatEntry is a single message sent by a player during a Prismata match.
+type ChatEntry struct {
+	// Player is the index of the player sending the message: 0 for the first
+	// player and 1 for the second.
+	Player int
+	// Turn is the number of the turn during which the message was sent,
+	// starting at 1.
+	Turn int
+	// Time is the offset of the message from the start of the match.
+	Time time.Duration
+	// Text is the text of the message.
+	Text string
+	// Emote is the emote carrying the message.
+	Emote *Emote
+}
+
+// Chat returns the messages sent by the players in chronological order. The
+// turn of each message is given by Turns, so opening emotes belong to the
+// first turn. It returns an error if a message cannot be assigned to a turn.
+func (r *Replay) Chat() ([]ChatEntry, error) {
+	turns, err := r.Turns()
+	if err != nil {
+		return nil, err
+	}
+
+	// turn maps the index of each command to the number of its turn.
+	ci := &r.CommandInfo
+	turn := make([]int, 0, len(ci.CommandList))
+	for _, t := range turns {
+		for range t.Commands {
+			turn = append(turn, t.Number)
+		}
+	}
+
+	var chat []ChatEntry
+	for i := range ci.CommandList {
+		cmd := &ci.CommandList[i]
+		e, ok := cmd.Emote()
+		if !ok {
+			continue
+		}
+
+		if i >= len(turn) {
+			return nil, fmt.Errorf("command %d is not part of any turn", i)
+		}
+
+		if i >= len(ci.CommandTimes) {
+			return nil, fmt.Errorf("missing time of command %d", i)
+		}
+
+		// The ID of an emote is the index of the sending player, who need
+		// not be the player taking the turn.
+		chat = append(chat, ChatEntry{
+			Player: cmd.ID,
+			Turn:   turn[i],
+			Time:   seconds(ci.CommandTimes[i]),
+			Text:   e.Text,
+			Emote:  e,
+		})
+	}
+
+	return chat, nil
+}
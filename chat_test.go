@@ -0,0 +1,142 @@
+package prismata
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChat(t *testing.T) {
+	var cases = []struct {
+		name  string
+		file  string
+		count int
+		first ChatEntry
+		last  ChatEntry
+	}{
+		{
+			"Pass: replay 1",
+			testFile1,
+			0,
+			ChatEntry{},
+			ChatEntry{},
+		},
+		{
+			"Pass: replay 2",
+			testFile2,
+			7,
+			ChatEntry{Player: 0, Turn: 1, Time: 0, Text: "Greetings, swarmwielder."},
+			ChatEntry{Player: 1, Turn: 15, Time: seconds(127.64641333198547), Text: "How terribly rude of you."},
+		},
+		{
+			"Pass: replay 3",
+			testFile3,
+			6,
+			ChatEntry{Player: 1, Turn: 35, Time: seconds(413.96414586448674), Text: "I'll accept your gambit."},
+			ChatEntry{Player: 0, Turn: 66, Time: seconds(613.0613632459641), Text: "GG!"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			chat, err := r.Chat()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(chat) != tt.count {
+				t.Fatalf("got: <%v>, want: <%v>", len(chat), tt.count)
+			}
+
+			if tt.count == 0 {
+				return
+			}
+
+			for _, c := range []struct{ got, exp ChatEntry }{{chat[0], tt.first}, {chat[len(chat)-1], tt.last}} {
+				if c.got.Emote == nil || c.got.Emote.Text != c.got.Text {
+					t.Errorf("got: <%v>, want: <emote %q>", c.got.Emote, c.got.Text)
+				}
+
+				c.got.Emote = nil
+				if c.got != c.exp {
+					t.Errorf("got: <%+v>, want: <%+v>", c.got, c.exp)
+				}
+			}
+
+			for i := 1; i < len(chat); i++ {
+				if chat[i].Time < chat[i-1].Time {
+					t.Errorf("got: <%v>, want: <>= %v>", chat[i].Time, chat[i-1].Time)
+				}
+			}
+		})
+	}
+}
+
+func TestChatError(t *testing.T) {
+	emote := Cmd{Type: "emoteGG!", ID: 1, Params: &Emote{Text: "GG!"}}
+	var cases = []struct {
+		name string
+		ci   CmdInfo
+		fail bool
+	}{
+		{
+			"Pass: empty",
+			CmdInfo{},
+			false,
+		},
+		{
+			"Pass: emote",
+			CmdInfo{CommandList: []Cmd{emote}, CommandTimes: []float64{1}, ClicksPerTurn: []int{1}},
+			false,
+		},
+		{
+			"Error: missing click counts",
+			CmdInfo{CommandList: []Cmd{emote}, CommandTimes: []float64{1}},
+			true,
+		},
+		{
+			"Error: missing command time",
+			CmdInfo{CommandList: []Cmd{emote}, ClicksPerTurn: []int{1}},
+			true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Replay{CommandInfo: tt.ci}
+			_, err := r.Chat()
+			assertError(t, err, tt.fail)
+		})
+	}
+}
+
+func TestChatInfoJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  string
+	}{
+		{"Pass: empty object", `{"chatInfo":{}}`, `{}`},
+		{"Pass: contents", `{"chatInfo":{"lines":["gl hf"]}}`, `{"lines":["gl hf"]}`},
+		{"Pass: missing", `{}`, `{}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Replay
+			if err := json.Unmarshal([]byte(tt.data), &r); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(r.ChatInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", string(b), tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package prismata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the root of the Prismata AWS server hosting saved replays.
+	DefaultBaseURL = root
+	// DefaultExtension is the file extension of replays on the Prismata AWS server.
+	DefaultExtension = extension
+	// DefaultMaxSize is the default maximum decompressed size of a replay.
+	// Replays from ranked matches are well under a megabyte.
+	DefaultMaxSize = 64 << 20
+)
+
+// Client retrieves replays from a Prismata replay server. The zero value is
+// ready to use and fetches from the Prismata AWS server with http.DefaultClient.
+type Client struct {
+	// BaseURL is the root URL replay files are requested from. Defaults to
+	// DefaultBaseURL.
+	BaseURL string
+	// HTTPClient is the client used to send requests. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+	// UserAgent, if set, is sent as the User-Agent header of each request.
+	UserAgent string
+	// Extension is appended to the replay code to form the file name.
+	// Defaults to DefaultExtension.
+	Extension string
+	// Retry is the policy applied to failed downloads. A nil policy makes a
+	// single attempt.
+	Retry *RetryPolicy
+	// MaxSize is the maximum decompressed size of a replay in bytes. Larger
+	// replays are rejected with ErrReplayTooLarge. Defaults to
+	// DefaultMaxSize.
+	MaxSize int64
+	// Cache, if set, stores the raw gzipped replays downloaded by the client
+	// and serves later requests for the same code without a download.
+	Cache Cache
+}
+
+// defaultClient is the Client used by the package-level functions.
+var defaultClient = &Client{}
+
+// Get returns the replay corresponding to the provided code, which may be in
+// any form accepted by ParseCode. Invalid codes are rejected with an error
+// wrapping ErrInvalidCode before any request is made. If the server has
+// no such replay, the returned error satisfies errors.Is(err, ErrReplayNotFound).
+// Other unexpected responses are reported as a *HTTPStatusError. The provided
+// context governs the request as well as the decompression and decoding of the
+// response. Failed attempts are retried according to the client's Retry policy.
+func (c *Client) Get(ctx context.Context, code string) (*Replay, error) {
+	replay, _, err := c.fetch(ctx, code)
+	return replay, err
+}
+
+// fetch retrieves the replay of the provided code, retrying failed attempts,
+// and returns it with the number of response body bytes read over all
+// attempts. Replays found in the client's cache are returned without any
+// request.
+func (c *Client) fetch(ctx context.Context, s string) (*Replay, int64, error) {
+	parsed, err := ParseCode(s)
+	if err != nil {
+		return nil, 0, err
+	}
+	code := parsed.String()
+
+	if c.Cache != nil {
+		if raw, ok, err := c.Cache.Get(code); err == nil && ok {
+			replay, err := c.decodeGzip(ctx, code, bytes.NewReader(raw))
+			if err == nil {
+				return replay, 0, nil
+			}
+		}
+	}
+
+	var total int64
+	p := c.Retry
+	for n := 1; ; n++ {
+		replay, size, err := c.get(ctx, code)
+		total += size
+
+		a := Attempt{Code: code, Number: n, Err: err}
+		var se *HTTPStatusError
+		if errors.As(err, &se) {
+			a.StatusCode = se.StatusCode
+		}
+
+		retry := err != nil && ctx.Err() == nil && p.retry(n, err)
+		if retry {
+			a.Delay = p.delay(n, err)
+		}
+		p.observe(a)
+
+		if !retry {
+			return replay, total, err
+		}
+
+		if err := sleep(ctx, a.Delay); err != nil {
+			return nil, total, err
+		}
+	}
+}
+
+// get makes a single attempt at retrieving the replay of the provided code and
+// returns it with the number of response body bytes read.
+func (c *Client) get(ctx context.Context, code string) (*Replay, int64, error) {
+	req, err := c.request(ctx, code)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := send(c.httpClient(), req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, &HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			URL:        req.URL.String(),
+			RetryAfter: retryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	body := &countReader{r: resp.Body}
+	if c.Cache == nil {
+		replay, err := c.decodeGzip(ctx, code, body)
+		return replay, body.n, err
+	}
+
+	raw, err := ioutil.ReadAll(&ctxReader{ctx, body})
+	if err != nil {
+		return nil, body.n, err
+	}
+
+	replay, err := c.decodeGzip(ctx, code, bytes.NewReader(raw))
+	if err != nil {
+		return nil, body.n, err
+	}
+
+	// A replay that cannot be cached can still be returned.
+	c.Cache.Put(code, raw)
+
+	return replay, body.n, nil
+}
+
+// decodeGzip decompresses and decodes the gzipped replay of the provided code
+// as it is read, without buffering the decompressed replay.
+func (c *Client) decodeGzip(ctx context.Context, code string, r io.Reader) (*Replay, error) {
+	zr, err := gzip.NewReader(&ctxReader{ctx, r})
+	if err != nil {
+		return nil, fmt.Errorf("cannot decompress replay %s: %w", code, err)
+	}
+	defer zr.Close()
+
+	lr := &limitReader{r: zr, n: c.maxSize()}
+	replay, err := Decode(&ctxReader{ctx, lr})
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode replay %s: %w", code, err)
+	}
+
+	return replay, nil
+}
+
+// request returns a GET request for the replay file of the provided code.
+func (c *Client) request(ctx context.Context, code string) (*http.Request, error) {
+	req, err := request(c.url(code))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	return req.WithContext(ctx), nil
+}
+
+// url returns the location of the replay file of the provided code.
+func (c *Client) url(code string) string {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	if base[len(base)-1] != '/' {
+		base += "/"
+	}
+
+	ext := c.Extension
+	if ext == "" {
+		ext = DefaultExtension
+	}
+
+	return base + escapeCode(code) + ext
+}
+
+// escapeCode escapes the code for use as a path segment. '+' is escaped as
+// well, since the replay server decodes it as a space.
+func escapeCode(code string) string {
+	return strings.ReplaceAll(url.PathEscape(code), "+", "%2B")
+}
+
+func (c *Client) maxSize() int64 {
+	if c.MaxSize > 0 {
+		return c.MaxSize
+	}
+	return DefaultMaxSize
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
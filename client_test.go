@@ -0,0 +1,368 @@
+package prismata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// testServer returns a server that serves the testdata directory and records
+// the User-Agent of the last request into ua.
+func testServer(ua *string) *httptest.Server {
+	fs := http.FileServer(http.Dir("testdata"))
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua != nil {
+			*ua = r.Header.Get("User-Agent")
+		}
+		fs.ServeHTTP(w, r)
+	}))
+}
+
+func TestClientGet(t *testing.T) {
+	var ua string
+	ts := testServer(&ua)
+	defer ts.Close()
+
+	var cases = []struct {
+		name string
+		code string
+		exp  Replay
+		fail bool
+	}{
+		{
+			"Pass: replay 1",
+			replaycode1,
+			Replay{Code: "ib0Qt-pp8PL", EndTimeUnix: 1521092570.323161},
+			false,
+		},
+		{
+			"Pass: replay 2",
+			replaycode2,
+			Replay{Code: "VyrET-IGxyL", EndTimeUnix: 1532955756.487255},
+			false,
+		},
+		{
+			"Pass: replay 3",
+			replaycode3,
+			Replay{Code: "yjUKQ-HzFRz", EndTimeUnix: 1533169252.757027},
+			false,
+		},
+		{
+			"Error: missing replay",
+			"aaaaa-bbbbb",
+			Replay{},
+			true,
+		},
+	}
+
+	c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client(), UserAgent: "prismata-test"}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := c.Get(context.Background(), tt.code)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if r.Code != tt.exp.Code {
+				t.Errorf("got: <%v>, want: <%v>", r.Code, tt.exp.Code)
+			}
+
+			if r.EndTimeUnix != tt.exp.EndTimeUnix {
+				t.Errorf("got: <%v>, want: <%v>", r.EndTimeUnix, tt.exp.EndTimeUnix)
+			}
+
+			if ua != c.UserAgent {
+				t.Errorf("got: <%v>, want: <%v>", ua, c.UserAgent)
+			}
+		})
+	}
+}
+
+func TestClientGetError(t *testing.T) {
+	var cases = []struct {
+		name     string
+		status   int
+		body     string
+		notFound bool
+		isStatus bool
+	}{
+		{"Error: forbidden", http.StatusForbidden, "", true, true},
+		{"Error: not found", http.StatusNotFound, "", true, true},
+		{"Error: server error", http.StatusInternalServerError, "", false, true},
+		{"Error: invalid gzip", http.StatusOK, "not gzip", false, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client()}
+			_, err := c.Get(context.Background(), replaycode1)
+			assertError(t, err, true)
+
+			if errors.Is(err, ErrReplayNotFound) != tt.notFound {
+				t.Errorf("got: <%v>, want: <%v>", errors.Is(err, ErrReplayNotFound), tt.notFound)
+			}
+
+			var se *HTTPStatusError
+			if errors.As(err, &se) != tt.isStatus {
+				t.Fatalf("got: <%v>, want: <%v>", errors.As(err, &se), tt.isStatus)
+			}
+
+			if tt.isStatus && se.StatusCode != tt.status {
+				t.Errorf("got: <%v>, want: <%v>", se.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestClientGetCancel(t *testing.T) {
+	gz, err := ioutil.ReadFile("testdata/" + replaycode1 + extension)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases = []struct {
+		name    string
+		handler func(w http.ResponseWriter, r *http.Request)
+	}{
+		{
+			"Error: stalled response",
+			func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+		},
+		{
+			"Error: stalled body",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Write(gz[:len(gz)/2])
+				w.(http.Flusher).Flush()
+				<-r.Context().Done()
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(tt.handler))
+			defer ts.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+			defer cancel()
+
+			c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client()}
+			_, err := c.Get(ctx, replaycode1)
+			assertError(t, err, true)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("got: <%v>, want: <%v>", err, context.DeadlineExceeded)
+			}
+		})
+	}
+}
+
+func TestCtxReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	f, err := os.Open(testFile1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	_, err = Decode(&ctxReader{ctx, f})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got: <%v>, want: <%v>", err, context.Canceled)
+	}
+}
+
+func TestClientGetMaxSize(t *testing.T) {
+	ts := testServer(nil)
+	defer ts.Close()
+
+	var cases = []struct {
+		name string
+		max  int64
+		fail bool
+	}{
+		{"Pass: default", 0, false},
+		{"Pass: exact size", 30512, false},
+		{"Error: one byte short", 30511, true},
+		{"Error: tiny", 1, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client(), MaxSize: tt.max}
+			_, err := c.Get(context.Background(), replaycode1)
+			assertError(t, err, tt.fail)
+
+			if tt.fail && !errors.Is(err, ErrReplayTooLarge) {
+				t.Errorf("got: <%v>, want: <%v>", err, ErrReplayTooLarge)
+			}
+		})
+	}
+}
+
+func TestLimitReader(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		n    int64
+		fail bool
+	}{
+		{"Pass: under limit", "abc", 5, false},
+		{"Pass: at limit", "abcde", 5, false},
+		{"Pass: empty", "", 0, false},
+		{"Error: over limit", "abcdef", 5, true},
+		{"Error: zero limit", "a", 0, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := ioutil.ReadAll(&limitReader{r: bytes.NewBufferString(tt.data), n: tt.n})
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				if !errors.Is(err, ErrReplayTooLarge) {
+					t.Errorf("got: <%v>, want: <%v>", err, ErrReplayTooLarge)
+				}
+				return
+			}
+
+			if string(b) != tt.data {
+				t.Errorf("got: <%v>, want: <%v>", string(b), tt.data)
+			}
+		})
+	}
+}
+
+// unzip decompresses the whole gzipped replay into memory.
+func unzip(r io.Reader) ([]byte, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return ioutil.ReadAll(zr)
+}
+
+// benchmarkFiles are the gzipped testdata replays decoded by the benchmarks.
+var benchmarkFiles = []string{replaycode1, replaycode2, replaycode3}
+
+// BenchmarkDecodeBuffered measures the decompress-then-decode approach of
+// reading the whole replay into memory before decoding it.
+func BenchmarkDecodeBuffered(b *testing.B) {
+	for _, code := range benchmarkFiles {
+		gz, err := ioutil.ReadFile("testdata/" + code + extension)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(code, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				raw, err := unzip(bytes.NewReader(gz))
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if _, err := Decode(bytes.NewBuffer(raw)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkDecodeStream measures the streaming decode used by Client.
+func BenchmarkDecodeStream(b *testing.B) {
+	c := &Client{}
+	ctx := context.Background()
+	for _, code := range benchmarkFiles {
+		gz, err := ioutil.ReadFile("testdata/" + code + extension)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(code, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := c.decodeGzip(ctx, code, bytes.NewReader(gz)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestClientURL(t *testing.T) {
+	var cases = []struct {
+		name string
+		c    Client
+		code string
+		exp  string
+	}{
+		{
+			"Pass: defaults",
+			Client{},
+			replaycode1,
+			root + replaycode1 + extension,
+		},
+		{
+			"Pass: custom base",
+			Client{BaseURL: "http://mirror.local/replays/"},
+			replaycode1,
+			"http://mirror.local/replays/" + replaycode1 + extension,
+		},
+		{
+			"Pass: custom base without slash",
+			Client{BaseURL: "http://mirror.local/replays"},
+			replaycode1,
+			"http://mirror.local/replays/" + replaycode1 + extension,
+		},
+		{
+			"Pass: custom extension",
+			Client{BaseURL: "http://mirror.local/", Extension: ".json"},
+			replaycode1,
+			"http://mirror.local/" + replaycode1 + ".json",
+		},
+		{
+			"Pass: code with plus",
+			Client{BaseURL: "http://mirror.local/"},
+			"6pRv4-b+XBe",
+			"http://mirror.local/6pRv4-b%2BXBe" + extension,
+		},
+		{
+			"Pass: code with at sign",
+			Client{BaseURL: "http://mirror.local/"},
+			"6pRv4-b@XBe",
+			"http://mirror.local/6pRv4-b@XBe" + extension,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.c.url(tt.code)
+			if u != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", u, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package prismata
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrInvalidCode is returned, wrapped with a description of the problem, when
+// a string is not a valid replay code.
+var ErrInvalidCode = errors.New("invalid replay code")
+
+// codeQueryKeys are the query parameters that carry a replay code in links
+// shared from the game or from replay sites.
+var codeQueryKeys = []string{"r", "replay", "code"}
+
+// Code is a replay code such as "ib0Qt-pp8PL": two groups of five
+// characters separated by a hyphen.
+type Code string
+
+// ParseCode returns the replay code contained in s. Besides bare codes, s may
+// be surrounded by whitespace, or be a link to the replay file on the replay
+// server or a share link carrying the code as a query parameter, such as
+// "http://play.prismata.net/?r=ib0Qt-pp8PL".
+func ParseCode(s string) (Code, error) {
+	raw := strings.TrimSpace(s)
+	if strings.Contains(raw, "/") || strings.Contains(raw, "?") {
+		var err error
+		raw, err = codeFromURL(raw)
+		if err != nil {
+			return "", fmt.Errorf("%w %q: %v", ErrInvalidCode, s, err)
+		}
+	}
+
+	raw = strings.TrimSuffix(raw, extension)
+	raw = strings.TrimSuffix(raw, ".json")
+
+	if err := validateCode(raw); err != nil {
+		return "", fmt.Errorf("%w %q: %v", ErrInvalidCode, s, err)
+	}
+
+	return Code(raw), nil
+}
+
+// String returns the code as a string.
+func (c Code) String() string {
+	return string(c)
+}
+
+// codeFromURL extracts the candidate replay code from a link.
+func codeFromURL(s string) (string, error) {
+	if !strings.Contains(s, "://") && !strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "?") {
+		s = "http://" + s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", errors.New("malformed link")
+	}
+
+	for _, k := range codeQueryKeys {
+		if v := queryValue(u.RawQuery, k); v != "" {
+			return strings.TrimSpace(v), nil
+		}
+	}
+
+	if base := path.Base(u.Path); base != "/" && base != "." {
+		return base, nil
+	}
+
+	return "", errors.New("link does not contain a replay code")
+}
+
+// queryValue returns the first value of the key in the raw query. Unlike
+// url.Values, it keeps '+' as is rather than decoding it as a space, since
+// '+' may appear in replay codes.
+func queryValue(raw, key string) string {
+	for _, kv := range strings.Split(raw, "&") {
+		k, v, _ := strings.Cut(kv, "=")
+		if k, err := url.PathUnescape(k); err != nil || k != key {
+			continue
+		}
+
+		v, err := url.PathUnescape(v)
+		if err != nil {
+			return ""
+		}
+		return v
+	}
+
+	return ""
+}
+
+// validateCode reports why s is not a well-formed replay code, if it is not.
+func validateCode(s string) error {
+	if len(s) != 11 {
+		return fmt.Errorf("length is %d, want 11", len(s))
+	}
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if i == 5 {
+			if ch != '-' {
+				return fmt.Errorf("character %d is %q, want '-'", i+1, ch)
+			}
+			continue
+		}
+
+		if !isCodeChar(ch) {
+			return fmt.Errorf("character %d is %q, want a letter, digit, '@' or '+'", i+1, ch)
+		}
+	}
+
+	return nil
+}
+
+// isCodeChar reports whether ch may appear in either half of a replay code.
+func isCodeChar(ch byte) bool {
+	switch {
+	case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9':
+		return true
+	case ch == '@' || ch == '+':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,76 @@
+package prismata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseCode(t *testing.T) {
+	var cases = []struct {
+		name string
+		s    string
+		exp  Code
+		fail bool
+	}{
+		{"Pass: replay 1", replaycode1, Code(replaycode1), false},
+		{"Pass: replay 2", replaycode2, Code(replaycode2), false},
+		{"Pass: replay 3", replaycode3, Code(replaycode3), false},
+		{"Pass: special characters", "a+b@C-1@2+z", Code("a+b@C-1@2+z"), false},
+		{"Pass: surrounding whitespace", " \t" + replaycode1 + "\n", Code(replaycode1), false},
+		{"Pass: server link", root + replaycode1 + extension, Code(replaycode1), false},
+		{"Pass: server link without scheme", "saved-games-alpha.s3-website-us-east-1.amazonaws.com/" + replaycode2 + extension, Code(replaycode2), false},
+		{"Pass: share link", "http://play.prismata.net/?r=" + replaycode3, Code(replaycode3), false},
+		{"Pass: share link with other parameters", "https://play.prismata.net/?lang=en&r=" + replaycode1 + "#top", Code(replaycode1), false},
+		{"Pass: share link with plus", "http://play.prismata.net/?r=6pRv4-b+XBe", Code("6pRv4-b+XBe"), false},
+		{"Pass: share link with escaped plus", "http://play.prismata.net/?r=6pRv4-b%2BXBe", Code("6pRv4-b+XBe"), false},
+		{"Pass: file name", replaycode1 + extension, Code(replaycode1), false},
+		{"Error: empty", "", "", true},
+		{"Error: whitespace", "   ", "", true},
+		{"Error: too short", "ib0Qt-pp8P", "", true},
+		{"Error: too long", "ib0Qt-pp8PLx", "", true},
+		{"Error: missing hyphen", "ib0Qtxpp8PL", "", true},
+		{"Error: invalid character", "ib0Qt-pp8P!", "", true},
+		{"Error: path traversal", "../../etc/passwd", "", true},
+		{"Error: link without code", "http://play.prismata.net/", "", true},
+		{"Error: share link with invalid code", "http://play.prismata.net/?r=nope", "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCode(tt.s)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				if !errors.Is(err, ErrInvalidCode) {
+					t.Errorf("got: <%v>, want: <%v>", err, ErrInvalidCode)
+				}
+				return
+			}
+
+			if c != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", c, tt.exp)
+			}
+		})
+	}
+}
+
+func TestClientGetInvalidCode(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client()}
+	_, err := c.Get(context.Background(), "../secret")
+	if !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("got: <%v>, want: <%v>", err, ErrInvalidCode)
+	}
+
+	if requests != 0 {
+		t.Errorf("got: <%v>, want: <%v>", requests, 0)
+	}
+}
@@ -0,0 +1,126 @@
+package prismata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandKind represents the kind of a command in the click stream of a
+// replay, as given by the command's type.
+type CommandKind int
+
+const (
+	// CommandUnknown denotes a command type not known to this package.
+	CommandUnknown CommandKind = 0
+	// CommandInstClicked denotes a click on a unit instance in play.
+	CommandInstClicked CommandKind = 1
+	// CommandInstShiftClicked denotes a shift-click on a unit instance in
+	// play, which applies to every similar instance.
+	CommandInstShiftClicked CommandKind = 2
+	// CommandCardClicked denotes a click on a card in the supply, which buys
+	// a unit.
+	CommandCardClicked CommandKind = 3
+	// CommandCardShiftClicked denotes a shift-click on a card in the supply,
+	// which returns a bought unit.
+	CommandCardShiftClicked CommandKind = 4
+	// CommandSpaceClicked denotes the space bar or the end turn button, which
+	// advances the turn to its next phase.
+	CommandSpaceClicked CommandKind = 5
+	// CommandEndSwipe denotes the end of a swipe selecting several instances.
+	CommandEndSwipe CommandKind = 6
+	// CommandRevertClicked denotes a revert of every action of the turn.
+	CommandRevertClicked CommandKind = 7
+	// CommandUndoClicked denotes an undo of the last action.
+	CommandUndoClicked CommandKind = 8
+	// CommandEmote denotes an emote sent to the opponent.
+	CommandEmote CommandKind = 9
+)
+
+// commandTypes maps command types to their kind. Emote types carry the emote
+// text and are matched by prefix instead.
+var commandTypes = map[string]CommandKind{
+	"inst clicked":        CommandInstClicked,
+	"inst shift clicked":  CommandInstShiftClicked,
+	"card clicked":        CommandCardClicked,
+	"card shift clicked":  CommandCardShiftClicked,
+	"space clicked":       CommandSpaceClicked,
+	"end swipe processed": CommandEndSwipe,
+	"revert clicked":      CommandRevertClicked,
+	"undo clicked":        CommandUndoClicked,
+}
+
+// ParseCommandKind returns the kind of the provided command type. In strict
+// mode, an unknown type is an error; otherwise it is reported as
+// CommandUnknown.
+func ParseCommandKind(typ string, strict bool) (CommandKind, error) {
+	if k, ok := commandTypes[typ]; ok {
+		return k, nil
+	}
+
+	if strings.HasPrefix(typ, emotePrefix) && len(typ) > len(emotePrefix) {
+		return CommandEmote, nil
+	}
+
+	if strict {
+		return CommandUnknown, fmt.Errorf("unknown command type %q", typ)
+	}
+
+	return CommandUnknown, nil
+}
+
+func (k CommandKind) String() string {
+	switch k {
+	case CommandInstClicked:
+		return "inst clicked"
+	case CommandInstShiftClicked:
+		return "inst shift clicked"
+	case CommandCardClicked:
+		return "card clicked"
+	case CommandCardShiftClicked:
+		return "card shift clicked"
+	case CommandSpaceClicked:
+		return "space clicked"
+	case CommandEndSwipe:
+		return "end swipe processed"
+	case CommandRevertClicked:
+		return "revert clicked"
+	case CommandUndoClicked:
+		return "undo clicked"
+	case CommandEmote:
+		return "emote"
+	default:
+		return "Unknown"
+	}
+}
+
+// Kind returns the kind of the command, or CommandUnknown if its type is not
+// known.
+func (c *Cmd) Kind() CommandKind {
+	k, _ := ParseCommandKind(c.Type, false)
+	return k
+}
+
+// IsEndTurn reports whether the command advances the turn. A turn ends with
+// the last of these commands.
+func (c *Cmd) IsEndTurn() bool {
+	return c.Kind() == CommandSpaceClicked
+}
+
+// TargetsInstance reports whether the command applies to a unit instance in
+// play, in which case ID identifies the instance.
+func (c *Cmd) TargetsInstance() bool {
+	k := c.Kind()
+	return k == CommandInstClicked || k == CommandInstShiftClicked
+}
+
+// TargetsCard reports whether the command applies to a card in the supply, in
+// which case ID identifies the card.
+func (c *Cmd) TargetsCard() bool {
+	k := c.Kind()
+	return k == CommandCardClicked || k == CommandCardShiftClicked
+}
+
+// IsEmote reports whether the command is an emote.
+func (c *Cmd) IsEmote() bool {
+	return c.Kind() == CommandEmote
+}
@@ -0,0 +1,103 @@
+package prismata
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseCommandKind(t *testing.T) {
+	var cases = []struct {
+		name   string
+		typ    string
+		strict bool
+		exp    CommandKind
+		fail   bool
+	}{
+		{"Pass: inst clicked", "inst clicked", true, CommandInstClicked, false},
+		{"Pass: inst shift clicked", "inst shift clicked", true, CommandInstShiftClicked, false},
+		{"Pass: card clicked", "card clicked", true, CommandCardClicked, false},
+		{"Pass: card shift clicked", "card shift clicked", true, CommandCardShiftClicked, false},
+		{"Pass: space clicked", "space clicked", true, CommandSpaceClicked, false},
+		{"Pass: end swipe processed", "end swipe processed", true, CommandEndSwipe, false},
+		{"Pass: revert clicked", "revert clicked", true, CommandRevertClicked, false},
+		{"Pass: undo clicked", "undo clicked", true, CommandUndoClicked, false},
+		{"Pass: emote", "emoteGG!", true, CommandEmote, false},
+		{"Pass: unknown lenient", "teleport clicked", false, CommandUnknown, false},
+		{"Error: unknown strict", "teleport clicked", true, CommandUnknown, true},
+		{"Error: bare emote prefix", "emote", true, CommandUnknown, true},
+		{"Error: empty", "", true, CommandUnknown, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := ParseCommandKind(tt.typ, tt.strict)
+			assertError(t, err, tt.fail)
+
+			if k != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", k, tt.exp)
+			}
+		})
+	}
+}
+
+func TestCommandKindStrict(t *testing.T) {
+	for _, file := range []string{testFile1, testFile2, testFile3} {
+		t.Run("Pass: "+file, func(t *testing.T) {
+			f, err := os.Open(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			r, err := Decode(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, c := range r.CommandInfo.CommandList {
+				if _, err := ParseCommandKind(c.Type, true); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func TestCmdHelpers(t *testing.T) {
+	var cases = []struct {
+		name     string
+		c        Cmd
+		endTurn  bool
+		instance bool
+		card     bool
+		emote    bool
+	}{
+		{"Pass: inst clicked", Cmd{Type: "inst clicked"}, false, true, false, false},
+		{"Pass: inst shift clicked", Cmd{Type: "inst shift clicked"}, false, true, false, false},
+		{"Pass: card clicked", Cmd{Type: "card clicked"}, false, false, true, false},
+		{"Pass: card shift clicked", Cmd{Type: "card shift clicked"}, false, false, true, false},
+		{"Pass: space clicked", Cmd{Type: "space clicked"}, true, false, false, false},
+		{"Pass: emote", Cmd{Type: "emoteWally"}, false, false, false, true},
+		{"Pass: unknown", Cmd{Type: "teleport clicked"}, false, false, false, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.c.IsEndTurn() != tt.endTurn {
+				t.Errorf("got: <%v>, want: <%v>", tt.c.IsEndTurn(), tt.endTurn)
+			}
+
+			if tt.c.TargetsInstance() != tt.instance {
+				t.Errorf("got: <%v>, want: <%v>", tt.c.TargetsInstance(), tt.instance)
+			}
+
+			if tt.c.TargetsCard() != tt.card {
+				t.Errorf("got: <%v>, want: <%v>", tt.c.TargetsCard(), tt.card)
+			}
+
+			if tt.c.IsEmote() != tt.emote {
+				t.Errorf("got: <%v>, want: <%v>", tt.c.IsEmote(), tt.emote)
+			}
+		})
+	}
+}
@@ -1,14 +1,296 @@
 package prismata
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
 // Deck represents a collection of units.
 type Deck struct {
-	MergedDeck []Unit          `json:"mergedDeck"`
-	Base       [][]interface{} `json:"base"`
-	Name       string          `json:"deckName"`
-	Randomizer [][]string      `json:"randomizer"`
+	MergedDeck []Unit     `json:"mergedDeck"`
+	Base       []BaseSet  `json:"base"`
+	Name       string     `json:"deckName"`
+	Randomizer [][]string `json:"randomizer"`
+
+	// index maps the lowercase names and UI names of the units of MergedDeck
+	// to their position.
+	index map[string]int
+}
+
+// BaseSet is the set of base units available to a player, indexed like
+// PlayerInfo.
+type BaseSet []BaseEntry
+
+// BaseEntry is a unit of a base set and the number of copies of it available
+// to buy.
+type BaseEntry struct {
+	Name string
+	// Supply is the number of copies available to buy. If the entry does not
+	// specify one, Supply is 0, or the default supply of the unit once the
+	// entry is decoded as part of a Deck.
+	Supply int
+	// HasSupply reports whether the entry specifies its supply, which may be
+	// 0.
+	HasSupply bool
+}
+
+// SupplyFor returns the number of copies available to buy, given the rarity
+// of the unit.
+func (e *BaseEntry) SupplyFor(r Rarity) int {
+	if e.HasSupply {
+		return e.Supply
+	}
+
+	return DefaultSupply(r)
 }
 
-// AdvancedSet returns the set of advanced units for the given replay.
-func (d *Deck) AdvancedSet() []string {
-	return d.Randomizer[0]
+// UnmarshalJSON decodes the deck, resolving the supply of base entries that
+// do not specify one.
+func (d *Deck) UnmarshalJSON(b []byte) error {
+	type deck Deck
+	if err := json.Unmarshal(b, (*deck)(d)); err != nil {
+		return err
+	}
+
+	d.buildIndex()
+	for _, set := range d.Base {
+		for i := range set {
+			var r Rarity
+			if u, err := d.Unit(set[i].Name); err == nil {
+				r = u.Rarity
+			}
+			set[i].Supply = set[i].SupplyFor(r)
+		}
+	}
+
+	return nil
+}
+
+// DefaultSupply returns the number of copies available to buy of a unit of
+// the provided rarity when a deck does not say otherwise.
+func DefaultSupply(r Rarity) int {
+	switch r {
+	case RarityTrinket:
+		return 20
+	case RarityRare:
+		return 4
+	case RarityLegendary:
+		return 1
+	default:
+		return 10
+	}
+}
+
+// UnmarshalJSON decodes a base entry from either a unit name or a
+// [name, supply] pair; see HasSupply.
+func (e *BaseEntry) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*e = BaseEntry{Name: name}
+		return nil
+	}
+
+	var pair []interface{}
+	if err := json.Unmarshal(b, &pair); err != nil || len(pair) != 2 {
+		return fmt.Errorf("invalid base entry %s", b)
+	}
+
+	name, ok := pair[0].(string)
+	if !ok {
+		return fmt.Errorf("invalid base entry name %v", pair[0])
+	}
+
+	supply, err := count(pair[1])
+	if err != nil {
+		return err
+	}
+
+	*e = BaseEntry{Name: name, Supply: supply, HasSupply: true}
+	return nil
+}
+
+// MarshalJSON encodes the base entry as a [name, supply] pair, or as the unit
+// name alone if it does not specify a supply.
+func (e BaseEntry) MarshalJSON() ([]byte, error) {
+	if !e.HasSupply {
+		return json.Marshal(e.Name)
+	}
+
+	return json.Marshal([]interface{}{e.Name, e.Supply})
+}
+
+// AdvancedSet returns the set of advanced units for the given replay. In
+// formats where the players' sets differ, AdvancedSet returns the first
+// player's set.
+func (d *Deck) AdvancedSet() ([]string, error) {
+	return d.AdvancedSetFor(0)
+}
+
+// AdvancedSetFor returns the set of advanced units available to the player at
+// the provided index.
+func (d *Deck) AdvancedSetFor(player int) ([]string, error) {
+	if player < 0 || player >= len(d.Randomizer) {
+		return nil, fmt.Errorf("missing advanced set for player %d", player)
+	}
+
+	return d.Randomizer[player], nil
+}
+
+// CombinedSet returns the definitions of the advanced units available to
+// either player, in order of first appearance.
+func (d *Deck) CombinedSet() ([]*Unit, error) {
+	if len(d.Randomizer) == 0 {
+		return nil, errors.New("missing advanced set")
+	}
+
+	seen := make(map[string]bool)
+	var units []*Unit
+	for _, set := range d.Randomizer {
+		for _, name := range set {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			u, err := d.Unit(name)
+			if err != nil {
+				return nil, err
+			}
+			units = append(units, u)
+		}
+	}
+
+	return units, nil
+}
+
+// Unit returns the definition of the unit with the provided name or UI name,
+// ignoring case. Names take precedence over UI names.
+//
+// A decoded Deck looks units up through an index built while decoding.
+// Entries that no longer match MergedDeck, such as after it was modified, are
+// ignored in favor of scanning MergedDeck, as are lookups in a Deck built by
+// hand.
+func (d *Deck) Unit(name string) (*Unit, error) {
+	if i, ok := d.index[strings.ToLower(name)]; ok && i < len(d.MergedDeck) {
+		u := &d.MergedDeck[i]
+		if strings.EqualFold(u.Name, name) || strings.EqualFold(u.UIName, name) {
+			return u, nil
+		}
+	}
+
+	for i := range d.MergedDeck {
+		if strings.EqualFold(d.MergedDeck[i].Name, name) {
+			return &d.MergedDeck[i], nil
+		}
+	}
+
+	for i := range d.MergedDeck {
+		if u := &d.MergedDeck[i]; u.UIName != "" && strings.EqualFold(u.UIName, name) {
+			return u, nil
+		}
+	}
+
+	return nil, fmt.Errorf("missing definition of unit %q", name)
+}
+
+// buildIndex indexes the units of MergedDeck by name and UI name.
+func (d *Deck) buildIndex() {
+	d.index = make(map[string]int, len(d.MergedDeck)*2)
+	for i, u := range d.MergedDeck {
+		if u.UIName == "" {
+			continue
+		}
+		d.index[strings.ToLower(u.UIName)] = i
+	}
+
+	for i, u := range d.MergedDeck {
+		d.index[strings.ToLower(u.Name)] = i
+	}
+}
+
+// Units returns an iterator over the definitions of the units of the deck.
+func (d *Deck) Units() iter.Seq[*Unit] {
+	return func(yield func(*Unit) bool) {
+		for i := range d.MergedDeck {
+			if !yield(&d.MergedDeck[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ByRarity returns the definitions of the units of the deck of the provided
+// rarity.
+func (d *Deck) ByRarity(r Rarity) []*Unit {
+	var units []*Unit
+	for u := range d.Units() {
+		if u.Rarity == r {
+			units = append(units, u)
+		}
+	}
+
+	return units
+}
+
+// RandomUnits returns the definitions of the units of the deck that are not
+// part of the base set, such as the advanced units and the units they create.
+func (d *Deck) RandomUnits() []*Unit {
+	var units []*Unit
+	for u := range d.Units() {
+		if !u.IsBase() {
+			units = append(units, u)
+		}
+	}
+
+	return units
+}
+
+// DependencyGraph returns the direct dependencies of each unit of the deck
+// that needs other units, keyed by unit name.
+func (d *Deck) DependencyGraph() map[string][]string {
+	g := make(map[string][]string)
+	for u := range d.Units() {
+		if len(u.Needs) > 0 {
+			g[u.Name] = u.Needs
+		}
+	}
+
+	return g
+}
+
+// Dependencies returns the definitions of every unit the unit with the
+// provided name needs, directly or indirectly, in breadth-first order.
+func (d *Deck) Dependencies(name string) ([]*Unit, error) {
+	root, err := d.Unit(name)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{root.Name: true}
+	queue := []*Unit{root}
+	var deps []*Unit
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+
+		for _, n := range u.Needs {
+			dep, err := d.Unit(n)
+			if err != nil {
+				return nil, err
+			}
+
+			if seen[dep.Name] {
+				continue
+			}
+			seen[dep.Name] = true
+
+			deps = append(deps, dep)
+			queue = append(queue, dep)
+		}
+	}
+
+	return deps, nil
 }
@@ -1,7 +1,9 @@
 package prismata
 
 import (
+	"encoding/json"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -92,10 +94,528 @@ func TestAdvancedSet(t *testing.T) {
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			adv := tt.d.AdvancedSet()
+			adv, err := tt.d.AdvancedSet()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(adv, tt.exp) {
+				t.Errorf("got: <%v>, want: <%v>", adv, tt.exp)
+			}
+		})
+	}
+}
+
+func TestAdvancedSetError(t *testing.T) {
+	var cases = []struct {
+		name string
+		d    Deck
+	}{
+		{"Error: nil randomizer", Deck{}},
+		{"Error: empty randomizer", Deck{Randomizer: [][]string{}}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.d.AdvancedSet()
+			assertError(t, err, true)
+		})
+	}
+}
+
+func TestAdvancedSetFor(t *testing.T) {
+	asym := Deck{Randomizer: [][]string{{"Drake"}, {"Odin", "Barrier"}}}
+
+	var cases = []struct {
+		name   string
+		d      Deck
+		player int
+		exp    []string
+		fail   bool
+	}{
+		{"Pass: player one", asym, 0, []string{"Drake"}, false},
+		{"Pass: player two", asym, 1, []string{"Odin", "Barrier"}, false},
+		{"Error: negative player", asym, -1, nil, true},
+		{"Error: third player", asym, 2, nil, true},
+		{"Error: missing second set", Deck{Randomizer: [][]string{{"Drake"}}}, 1, nil, true},
+		{"Error: empty randomizer", Deck{}, 0, nil, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			adv, err := tt.d.AdvancedSetFor(tt.player)
+			assertError(t, err, tt.fail)
+
 			if !reflect.DeepEqual(adv, tt.exp) {
 				t.Errorf("got: <%v>, want: <%v>", adv, tt.exp)
 			}
 		})
 	}
 }
+
+func TestCombinedSet(t *testing.T) {
+	var cases = []struct {
+		name string
+		file string
+		exp  []string
+	}{
+		{
+			"Pass: replay 1",
+			testFile1,
+			[]string{"Xaetron", "Corpus", "Thermite Core", "Drake", "Thorium Dynamo", "Shadowfang", "The Wincer", "Nivo Charge"},
+		},
+		{
+			"Pass: replay 2",
+			testFile2,
+			[]string{"Synthesizer", "Valkyrion", "Blood Phage", "Defense Grid", "Infusion Grid", "Aegis", "Thorium Dynamo", "Nivo Charge", "Redeemer"},
+		},
+		{
+			"Pass: replay 3",
+			testFile3,
+			[]string{"Odin", "Mobile Animus", "Barrier", "Perforator", "Hannibull"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			for p := 0; p < 2; p++ {
+				adv, err := r.Deck.AdvancedSetFor(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(adv, tt.exp) {
+					t.Errorf("got: <%v>, want: <%v>", adv, tt.exp)
+				}
+			}
+
+			units, err := r.Deck.CombinedSet()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, u := range units {
+				names = append(names, u.Name)
+				if u.BuyCost.IsZero() {
+					t.Errorf("got: <%v>, want: <non-zero buy cost>", u.BuyCost)
+				}
+			}
+
+			if !reflect.DeepEqual(names, tt.exp) {
+				t.Errorf("got: <%v>, want: <%v>", names, tt.exp)
+			}
+		})
+	}
+}
+
+func TestCombinedSetError(t *testing.T) {
+	var cases = []struct {
+		name string
+		d    Deck
+	}{
+		{"Error: empty randomizer", Deck{}},
+		{"Error: undefined unit", Deck{Randomizer: [][]string{{"Drake"}}, MergedDeck: []Unit{{Name: "Odin"}}}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.d.CombinedSet()
+			assertError(t, err, true)
+		})
+	}
+}
+
+func TestCombinedSetAsymmetric(t *testing.T) {
+	d := Deck{
+		MergedDeck: []Unit{{Name: "Drake"}, {Name: "Odin"}, {Name: "Barrier"}},
+		Randomizer: [][]string{{"Drake", "Barrier"}, {"Odin", "Barrier"}},
+	}
+
+	units, err := d.CombinedSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []*Unit{&d.MergedDeck[0], &d.MergedDeck[2], &d.MergedDeck[1]}
+	if len(units) != len(exp) {
+		t.Fatalf("got: <%v>, want: <%v>", len(units), len(exp))
+	}
+
+	for i := range units {
+		if units[i] != exp[i] {
+			t.Errorf("got: <%v>, want: <%v>", units[i].Name, exp[i].Name)
+		}
+	}
+}
+
+func TestDeckBase(t *testing.T) {
+	exp := []BaseSet{
+		{
+			{"Engineer", 20, false},
+			{"Drone", 21, true},
+			{"Conduit", 10, false},
+			{"Blastforge", 10, false},
+			{"Animus", 10, false},
+			{"Forcefield", 20, false},
+			{"Gauss Cannon", 10, false},
+			{"Wall", 10, false},
+			{"Steelsplitter", 10, false},
+			{"Tarsier", 10, false},
+			{"Rhino", 10, false},
+		},
+		{
+			{"Engineer", 20, false},
+			{"Drone", 20, false},
+			{"Conduit", 10, false},
+			{"Blastforge", 10, false},
+			{"Animus", 10, false},
+			{"Forcefield", 20, false},
+			{"Gauss Cannon", 10, false},
+			{"Wall", 10, false},
+			{"Steelsplitter", 10, false},
+			{"Tarsier", 10, false},
+			{"Rhino", 10, false},
+		},
+	}
+
+	for _, file := range []string{testFile1, testFile2, testFile3} {
+		t.Run("Pass: "+file, func(t *testing.T) {
+			r := openReplay(t, file)
+			if !reflect.DeepEqual(r.Deck.Base, exp) {
+				t.Errorf("got: <%v>, want: <%v>", r.Deck.Base, exp)
+			}
+		})
+	}
+}
+
+func TestDeckBaseDecode(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  []BaseSet
+		fail bool
+	}{
+		{
+			"Pass: default supplies by rarity",
+			`{"mergedDeck": [{"name": "Drone", "rarity": "trinket"}, {"name": "Drake", "rarity": "rare"}, {"name": "Xaetron", "rarity": "legendary"}], "base": [["Drone", "Drake", "Xaetron", "Unlisted"]]}`,
+			[]BaseSet{{{"Drone", 20, false}, {"Drake", 4, false}, {"Xaetron", 1, false}, {"Unlisted", 10, false}}},
+			false,
+		},
+		{
+			"Pass: explicit supplies",
+			`{"mergedDeck": [{"name": "Drone", "rarity": "trinket"}], "base": [[["Drone", 7]], [["Drone", 0]]]}`,
+			[]BaseSet{{{"Drone", 7, true}}, {{"Drone", 0, true}}},
+			false,
+		},
+		{
+			"Error: pair without supply",
+			`{"base": [[["Drone"]]]}`,
+			nil,
+			true,
+		},
+		{
+			"Error: invalid supply",
+			`{"base": [[["Drone", "many"]]]}`,
+			nil,
+			true,
+		},
+		{
+			"Error: invalid entry",
+			`{"base": [[7]]}`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Deck
+			err := json.Unmarshal([]byte(tt.data), &d)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if !reflect.DeepEqual(d.Base, tt.exp) {
+				t.Errorf("got: <%v>, want: <%v>", d.Base, tt.exp)
+			}
+		})
+	}
+}
+
+func TestBaseEntryJSON(t *testing.T) {
+	var cases = []struct {
+		name   string
+		data   string
+		exp    BaseEntry
+		supply int
+		out    string
+	}{
+		{"Pass: name only", `"Drone"`, BaseEntry{"Drone", 0, false}, 20, `"Drone"`},
+		{"Pass: pair", `["Drone", 21]`, BaseEntry{"Drone", 21, true}, 21, `["Drone",21]`},
+		{"Pass: zero supply", `["Drone", 0]`, BaseEntry{"Drone", 0, true}, 0, `["Drone",0]`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var e BaseEntry
+			if err := json.Unmarshal([]byte(tt.data), &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if e != tt.exp {
+				t.Errorf("got: <%+v>, want: <%+v>", e, tt.exp)
+			}
+
+			if s := e.SupplyFor(RarityTrinket); s != tt.supply {
+				t.Errorf("got: <%v>, want: <%v>", s, tt.supply)
+			}
+
+			b, err := json.Marshal(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != tt.out {
+				t.Errorf("got: <%v>, want: <%v>", string(b), tt.out)
+			}
+		})
+	}
+}
+
+func TestDeckUnit(t *testing.T) {
+	var cases = []struct {
+		name   string
+		file   string
+		lookup string
+		exp    string
+		fail   bool
+	}{
+		{"Pass: exact name", testFile1, "Drake", "Drake", false},
+		{"Pass: lowercase name", testFile1, "drake", "Drake", false},
+		{"Pass: uppercase name", testFile2, "THORIUM DYNAMO", "Thorium Dynamo", false},
+		{"Pass: UI name", testFile1, "the wincer", "The Wincer", false},
+		{"Pass: base unit", testFile3, "gauss cannon", "Gauss Cannon", false},
+		{"Error: missing unit", testFile1, "Odin", "", true},
+		{"Error: empty name", testFile1, "", "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			u, err := r.Deck.Unit(tt.lookup)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if u.Name != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", u.Name, tt.exp)
+			}
+		})
+	}
+}
+
+func TestDeckUnitUIName(t *testing.T) {
+	d := Deck{
+		MergedDeck: []Unit{
+			{Name: "Thorium Dynamo", UIName: "Dynamo"},
+			{Name: "Dynamo"},
+		},
+	}
+
+	var cases = []struct {
+		name   string
+		lookup string
+		exp    string
+	}{
+		{"Pass: UI name", "thorium dynamo", "Thorium Dynamo"},
+		{"Pass: name over UI name", "dynamo", "Dynamo"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := d.Unit(tt.lookup)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if u.Name != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", u.Name, tt.exp)
+			}
+		})
+	}
+}
+
+func TestDeckUnitModified(t *testing.T) {
+	r := openReplay(t, testFile1)
+	d := &r.Deck
+
+	if _, err := d.Unit("Tarsier"); err != nil {
+		t.Fatal(err)
+	}
+
+	last := d.MergedDeck[len(d.MergedDeck)-1].Name
+	d.MergedDeck = d.MergedDeck[:1]
+
+	_, err := d.Unit(last)
+	assertError(t, err, true)
+
+	d.MergedDeck[0] = Unit{Name: "Odin"}
+	u, err := d.Unit("odin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u != &d.MergedDeck[0] {
+		t.Errorf("got: <%v>, want: <%v>", u.Name, d.MergedDeck[0].Name)
+	}
+
+	_, err = d.Unit("Tarsier")
+	assertError(t, err, true)
+}
+
+func TestDeckUnitConcurrent(t *testing.T) {
+	d := Deck{MergedDeck: []Unit{{Name: "Drake"}, {Name: "Odin", UIName: "Allfather"}}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, name := range []string{"drake", "allfather"} {
+				if _, err := d.Unit(name); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDeckUnits(t *testing.T) {
+	r := openReplay(t, testFile1)
+
+	n := 0
+	for u := range r.Deck.Units() {
+		if u != &r.Deck.MergedDeck[n] {
+			t.Errorf("got: <%v>, want: <%v>", u.Name, r.Deck.MergedDeck[n].Name)
+		}
+		n++
+	}
+
+	if n != len(r.Deck.MergedDeck) {
+		t.Errorf("got: <%v>, want: <%v>", n, len(r.Deck.MergedDeck))
+	}
+
+	for range r.Deck.Units() {
+		break
+	}
+}
+
+func TestDeckClassification(t *testing.T) {
+	r := openReplay(t, testFile1)
+
+	var cases = []struct {
+		name   string
+		rarity Rarity
+		exp    int
+	}{
+		{"Pass: trinket", RarityTrinket, 6},
+		{"Pass: normal", RarityNormal, 10},
+		{"Pass: rare", RarityRare, 4},
+		{"Pass: legendary", RarityLegendary, 2},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			units := r.Deck.ByRarity(tt.rarity)
+			if len(units) != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", len(units), tt.exp)
+			}
+
+			for _, u := range units {
+				if u.Rarity != tt.rarity {
+					t.Errorf("got: <%v>, want: <%v>", u.Rarity, tt.rarity)
+				}
+			}
+		})
+	}
+
+	random := r.Deck.RandomUnits()
+	if len(random)+len(r.Deck.Base[0]) != len(r.Deck.MergedDeck) {
+		t.Errorf("got: <%v>, want: <%v>", len(random), len(r.Deck.MergedDeck)-len(r.Deck.Base[0]))
+	}
+
+	for _, u := range random {
+		if u.IsBase() {
+			t.Errorf("got: <%v>, want: <random unit>", u.Name)
+		}
+	}
+}
+
+func TestDeckDependencies(t *testing.T) {
+	r := openReplay(t, testFile1)
+
+	g := r.Deck.DependencyGraph()
+	if !reflect.DeepEqual(g["Drake"], []string{"Blastforge"}) {
+		t.Errorf("got: <%v>, want: <%v>", g["Drake"], []string{"Blastforge"})
+	}
+
+	if _, ok := g["Engineer"]; ok {
+		t.Errorf("got: <%v>, want: <absent>", g["Engineer"])
+	}
+
+	var cases = []struct {
+		name string
+		d    *Deck
+		unit string
+		exp  []string
+		fail bool
+	}{
+		{"Pass: direct", &r.Deck, "Drake", []string{"Blastforge"}, false},
+		{"Pass: none", &r.Deck, "Engineer", nil, false},
+		{
+			"Pass: transitive",
+			&Deck{MergedDeck: []Unit{
+				{Name: "A", Needs: []string{"B", "C"}},
+				{Name: "B", Needs: []string{"D"}},
+				{Name: "C", Needs: []string{"A"}},
+				{Name: "D"},
+			}},
+			"A",
+			[]string{"B", "C", "D"},
+			false,
+		},
+		{"Error: missing unit", &r.Deck, "Odin", nil, true},
+		{
+			"Error: missing dependency",
+			&Deck{MergedDeck: []Unit{{Name: "A", Needs: []string{"B"}}}},
+			"A",
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, err := tt.d.Dependencies(tt.unit)
+			assertError(t, err, tt.fail)
+
+			var names []string
+			for _, u := range deps {
+				names = append(names, u.Name)
+			}
+
+			if !reflect.DeepEqual(names, tt.exp) {
+				t.Errorf("got: <%v>, want: <%v>", names, tt.exp)
+			}
+		})
+	}
+}
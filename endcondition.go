@@ -0,0 +1,39 @@
+package prismata
+
+import "fmt"
+
+// EndCondition represents the way a match ended, as recorded by the server.
+// Replays only record two conditions known to this package; any other value
+// is kept as decoded and reported by String as "Unknown(n)", so that it can
+// be told apart from the known conditions with IsKnown.
+type EndCondition int
+
+const (
+	// EndDecided denotes a match won by one of the players. Recorded replays
+	// do not show whether it also covers resignations and timeouts.
+	EndDecided EndCondition = 0
+	// EndDraw denotes a drawn match.
+	EndDraw EndCondition = 11
+)
+
+func (e EndCondition) String() string {
+	switch e {
+	case EndDecided:
+		return "Decided"
+	case EndDraw:
+		return "Draw"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(e))
+	}
+}
+
+// IsKnown reports whether the end condition is one of the conditions known
+// to this package.
+func (e EndCondition) IsKnown() bool {
+	return e == EndDecided || e == EndDraw
+}
+
+// IsDraw reports whether the match ended in a draw.
+func (e EndCondition) IsDraw() bool {
+	return e == EndDraw
+}
@@ -0,0 +1,75 @@
+package prismata
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEndCondition(t *testing.T) {
+	var cases = []struct {
+		name  string
+		e     EndCondition
+		str   string
+		known bool
+		draw  bool
+	}{
+		{"Pass: decided", EndDecided, "Decided", true, false},
+		{"Pass: draw", EndDraw, "Draw", true, true},
+		{"Pass: unknown", EndCondition(3), "Unknown(3)", false, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.e.String() != tt.str {
+				t.Errorf("got: <%v>, want: <%v>", tt.e.String(), tt.str)
+			}
+
+			if tt.e.IsKnown() != tt.known {
+				t.Errorf("got: <%v>, want: <%v>", tt.e.IsKnown(), tt.known)
+			}
+
+			if tt.e.IsDraw() != tt.draw {
+				t.Errorf("got: <%v>, want: <%v>", tt.e.IsDraw(), tt.draw)
+			}
+
+			b, err := json.Marshal(tt.e)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var e EndCondition
+			if err := json.Unmarshal(b, &e); err != nil {
+				t.Fatal(err)
+			}
+
+			if e != tt.e {
+				t.Errorf("got: <%v>, want: <%v>", e, tt.e)
+			}
+		})
+	}
+}
+
+func TestReplayEndCondition(t *testing.T) {
+	var cases = []struct {
+		name string
+		file string
+		exp  EndCondition
+	}{
+		{"Pass: replay 1", testFile1, EndDecided},
+		{"Pass: replay 2", testFile2, EndDecided},
+		{"Pass: replay 3", testFile3, EndDraw},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+			if r.EndCondition != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", r.EndCondition, tt.exp)
+			}
+
+			if r.EndCondition.IsDraw() != (r.Result == Draw) {
+				t.Errorf("got: <%v>, want: <%v>", r.EndCondition.IsDraw(), r.Result == Draw)
+			}
+		})
+	}
+}
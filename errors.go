@@ -0,0 +1,50 @@
+package prismata
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrReplayNotFound is returned when the replay server has no replay for the
+// requested code. The Prismata AWS server responds with 403 Forbidden rather
+// than 404 Not Found for missing replays, so both are treated as not found.
+var ErrReplayNotFound = errors.New("replay not found")
+
+// ErrReplayTooLarge is returned when a replay exceeds the maximum decompressed
+// size allowed by the Client.
+var ErrReplayTooLarge = errors.New("replay too large")
+
+// HTTPStatusError is returned when the replay server responds with a
+// non-200 status code.
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+	URL        string
+	// RetryAfter is the delay requested by the server's Retry-After header,
+	// or zero if the header was absent or invalid.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %q from %s", e.Status, e.URL)
+}
+
+// Is reports whether the status code denotes a missing replay, so that
+// errors.Is(err, ErrReplayNotFound) holds for 403 and 404 responses.
+func (e *HTTPStatusError) Is(target error) bool {
+	if target != ErrReplayNotFound {
+		return false
+	}
+
+	return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusForbidden
+}
+
+// ErrDraw is returned when a winner or loser is requested for a match that
+// ended in a draw.
+var ErrDraw = errors.New("match ended in a draw")
+
+// ErrUnrated is returned when rating information is requested for a match
+// that has none.
+var ErrUnrated = errors.New("match is unrated")
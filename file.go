@@ -0,0 +1,44 @@
+package prismata
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// gzipMagic is the header that starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Open reads the replay stored in the file at the provided path. The file may
+// contain either plain JSON, such as a replay saved from the game, or gzipped
+// JSON, such as a file downloaded from the replay server.
+func Open(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return DecodeAuto(f)
+}
+
+// DecodeAuto reads from the provided reader and decodes the JSON into a Replay,
+// first decompressing it if the data is gzipped.
+func DecodeAuto(r io.Reader) (*Replay, error) {
+	br := bufio.NewReader(r)
+
+	magic, _ := br.Peek(len(gzipMagic))
+	if !bytes.Equal(magic, gzipMagic) {
+		return Decode(br)
+	}
+
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return Decode(zr)
+}
@@ -0,0 +1,101 @@
+package prismata
+
+import (
+	"os"
+	"testing"
+)
+
+func TestOpen(t *testing.T) {
+	var cases = []struct {
+		name string
+		file string
+		exp  Replay
+		fail bool
+	}{
+		{
+			"Pass: replay 1 json",
+			testFile1,
+			Replay{Code: "ib0Qt-pp8PL", EndTimeUnix: 1521092570.323161},
+			false,
+		},
+		{
+			"Pass: replay 1 gzip",
+			"testdata/" + replaycode1 + extension,
+			Replay{Code: "ib0Qt-pp8PL", EndTimeUnix: 1521092570.323161},
+			false,
+		},
+		{
+			"Pass: replay 2 gzip",
+			"testdata/" + replaycode2 + extension,
+			Replay{Code: "VyrET-IGxyL", EndTimeUnix: 1532955756.487255},
+			false,
+		},
+		{
+			"Pass: replay 3 gzip",
+			"testdata/" + replaycode3 + extension,
+			Replay{Code: "yjUKQ-HzFRz", EndTimeUnix: 1533169252.757027},
+			false,
+		},
+		{
+			"Error: empty",
+			testFileEmpty,
+			Replay{},
+			true,
+		},
+		{
+			"Error: missing file",
+			"testdata/missing.json",
+			Replay{},
+			true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Open(tt.file)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if r.Code != tt.exp.Code {
+				t.Errorf("got: <%v>, want: <%v>", r.Code, tt.exp.Code)
+			}
+
+			if r.EndTimeUnix != tt.exp.EndTimeUnix {
+				t.Errorf("got: <%v>, want: <%v>", r.EndTimeUnix, tt.exp.EndTimeUnix)
+			}
+		})
+	}
+}
+
+func TestDecodeAuto(t *testing.T) {
+	var cases = []struct {
+		name string
+		file string
+		exp  string
+	}{
+		{"Pass: json", testFile2, replaycode2},
+		{"Pass: gzip", "testdata/" + replaycode2 + extension, replaycode2},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			r, err := DecodeAuto(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if r.Code != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", r.Code, tt.exp)
+			}
+		})
+	}
+}
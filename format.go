@@ -0,0 +1,51 @@
+package prismata
+
+import "fmt"
+
+// Format represents the game format of a match, such as a ranked match. The
+// recorded replays are all ranked matches, so FormatRanked is the only format
+// known to this package; other formats decode to their raw value and print as
+// "Unknown(n)".
+type Format int
+
+const (
+	// FormatRanked denotes a rated ladder match between two players.
+	FormatRanked Format = 200
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatRanked:
+		return "Ranked"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(f))
+	}
+}
+
+// IsKnown reports whether the format is one of the formats known to this
+// package.
+func (f Format) IsKnown() bool {
+	return f == FormatRanked
+}
+
+// IsRated reports whether matches of the format affect the ladder ratings of
+// the players.
+func (f Format) IsRated() bool {
+	return f == FormatRanked
+}
+
+// IsRated reports whether the match is a ranked match between two players.
+func (r *Replay) IsRated() bool {
+	return r.Format.IsRated() && !r.IsVersusBot()
+}
+
+// IsVersusBot reports whether either player of the match is a bot.
+func (r *Replay) IsVersusBot() bool {
+	for _, p := range r.PlayerInfo {
+		if p.Bot != "" {
+			return true
+		}
+	}
+
+	return false
+}
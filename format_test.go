@@ -0,0 +1,63 @@
+package prismata
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	var cases = []struct {
+		name  string
+		f     Format
+		str   string
+		known bool
+		rated bool
+	}{
+		{"Pass: ranked", FormatRanked, "Ranked", true, true},
+		{"Pass: unknown", Format(300), "Unknown(300)", false, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.f.String() != tt.str {
+				t.Errorf("got: <%v>, want: <%v>", tt.f.String(), tt.str)
+			}
+
+			if tt.f.IsKnown() != tt.known {
+				t.Errorf("got: <%v>, want: <%v>", tt.f.IsKnown(), tt.known)
+			}
+
+			if tt.f.IsRated() != tt.rated {
+				t.Errorf("got: <%v>, want: <%v>", tt.f.IsRated(), tt.rated)
+			}
+		})
+	}
+}
+
+func TestReplayFormat(t *testing.T) {
+	for _, file := range []string{testFile1, testFile2, testFile3} {
+		t.Run("Pass: "+file, func(t *testing.T) {
+			r := openReplay(t, file)
+
+			if r.Format != FormatRanked {
+				t.Errorf("got: <%v>, want: <%v>", r.Format, FormatRanked)
+			}
+
+			if !r.IsRated() {
+				t.Errorf("got: <%v>, want: <%v>", r.IsRated(), true)
+			}
+
+			if r.IsVersusBot() {
+				t.Errorf("got: <%v>, want: <%v>", r.IsVersusBot(), false)
+			}
+
+			r.PlayerInfo[1].Bot = "MasterBot"
+			if !r.IsVersusBot() || r.IsRated() {
+				t.Errorf("got: <%v, %v>, want: <%v, %v>", r.IsVersusBot(), r.IsRated(), true, false)
+			}
+
+			r.PlayerInfo[1].Bot = ""
+			r.Format = Format(300)
+			if r.IsRated() {
+				t.Errorf("got: <%v>, want: <%v>", r.IsRated(), false)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package prismata
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// InitInfo contains information on the starting position of each player in a
+// Prismata replay.
+type InitInfo struct {
+	Cards     [][]InitCard `json:"initCards"`
+	Resources []Resources  `json:"initResources"`
+}
+
+// InitCard is a number of copies of a unit a player starts with.
+type InitCard struct {
+	Count int
+	Name  string
+}
+
+// standardStart is the starting position of each player in a standard game.
+var standardStart = [][]InitCard{
+	{{6, "Drone"}, {2, "Engineer"}},
+	{{7, "Drone"}, {2, "Engineer"}},
+}
+
+// StartingUnits returns the units the player at the provided index starts
+// with.
+func (i *InitInfo) StartingUnits(player int) ([]InitCard, error) {
+	if player < 0 || player >= len(i.Cards) {
+		return nil, fmt.Errorf("missing starting units for player %d", player)
+	}
+
+	return i.Cards[player], nil
+}
+
+// StartingResources returns the resources the player at the provided index
+// starts with.
+func (i *InitInfo) StartingResources(player int) (Resources, error) {
+	if player < 0 || player >= len(i.Resources) {
+		return Resources{}, fmt.Errorf("missing starting resources for player %d", player)
+	}
+
+	return i.Resources[player], nil
+}
+
+// IsStandard reports whether both players start from the standard position:
+// six Drones and two Engineers for the first player, seven Drones and two
+// Engineers for the second, and no resources. Custom and handicap games
+// start from other positions.
+func (i *InitInfo) IsStandard() bool {
+	if len(i.Cards) != len(standardStart) || len(i.Resources) != len(standardStart) {
+		return false
+	}
+
+	for p, exp := range standardStart {
+		if !i.Resources[p].IsZero() || !sameCards(i.Cards[p], exp) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameCards reports whether a and b hold the same number of copies of each
+// unit, regardless of order.
+func sameCards(a, b []InitCard) bool {
+	count := make(map[string]int)
+	for _, c := range a {
+		count[c.Name] += c.Count
+	}
+	for _, c := range b {
+		count[c.Name] -= c.Count
+	}
+
+	for _, n := range count {
+		if n != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UnmarshalJSON decodes a starting card from its [count, name] pair.
+func (c *InitCard) UnmarshalJSON(b []byte) error {
+	var pair []interface{}
+	if err := json.Unmarshal(b, &pair); err != nil || len(pair) != 2 {
+		return fmt.Errorf("invalid starting card %s", b)
+	}
+
+	n, err := count(pair[0])
+	if err != nil {
+		return err
+	}
+
+	name, ok := pair[1].(string)
+	if !ok {
+		return fmt.Errorf("invalid starting card name %v", pair[1])
+	}
+
+	*c = InitCard{Count: n, Name: name}
+	return nil
+}
+
+// MarshalJSON encodes the starting card as a [count, name] pair.
+func (c InitCard) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{c.Count, c.Name})
+}
@@ -0,0 +1,148 @@
+package prismata
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestInitInfo(t *testing.T) {
+	for _, file := range []string{testFile1, testFile2, testFile3} {
+		t.Run("Pass: "+file, func(t *testing.T) {
+			r := openReplay(t, file)
+
+			var cases = []struct {
+				player int
+				drones int
+			}{
+				{0, 6},
+				{1, 7},
+			}
+
+			for _, tt := range cases {
+				units, err := r.InitInfo.StartingUnits(tt.player)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				exp := []InitCard{{tt.drones, "Drone"}, {2, "Engineer"}}
+				if !reflect.DeepEqual(units, exp) {
+					t.Errorf("got: <%v>, want: <%v>", units, exp)
+				}
+
+				res, err := r.InitInfo.StartingResources(tt.player)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !res.IsZero() {
+					t.Errorf("got: <%v>, want: <%v>", res, Resources{})
+				}
+			}
+
+			if !r.InitInfo.IsStandard() {
+				t.Errorf("got: <%v>, want: <%v>", false, true)
+			}
+		})
+	}
+}
+
+func TestInitInfoError(t *testing.T) {
+	var cases = []struct {
+		name   string
+		i      InitInfo
+		player int
+	}{
+		{"Error: empty", InitInfo{}, 0},
+		{"Error: negative player", InitInfo{Cards: standardStart, Resources: []Resources{{}, {}}}, -1},
+		{"Error: third player", InitInfo{Cards: standardStart, Resources: []Resources{{}, {}}}, 2},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.i.StartingUnits(tt.player)
+			assertError(t, err, true)
+
+			_, err = tt.i.StartingResources(tt.player)
+			assertError(t, err, true)
+		})
+	}
+}
+
+func TestInitInfoIsStandard(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  bool
+	}{
+		{
+			"Pass: standard",
+			`{"initCards": [[[6, "Drone"], [2, "Engineer"]], [[7, "Drone"], [2, "Engineer"]]], "initResources": ["0", "0"]}`,
+			true,
+		},
+		{
+			"Pass: standard in other order",
+			`{"initCards": [[[2, "Engineer"], [6, "Drone"]], [[7, "Drone"], [2, "Engineer"]]], "initResources": ["0", 0]}`,
+			true,
+		},
+		{
+			"Pass: handicap units",
+			`{"initCards": [[[6, "Drone"], [2, "Engineer"]], [[5, "Drone"], [2, "Engineer"]]], "initResources": ["0", "0"]}`,
+			false,
+		},
+		{
+			"Pass: handicap resources",
+			`{"initCards": [[[6, "Drone"], [2, "Engineer"]], [[7, "Drone"], [2, "Engineer"]]], "initResources": ["0", "3G"]}`,
+			false,
+		},
+		{
+			"Pass: custom units",
+			`{"initCards": [[[6, "Drone"], [2, "Engineer"], [1, "Wall"]], [[7, "Drone"], [2, "Engineer"]]], "initResources": ["0", "0"]}`,
+			false,
+		},
+		{
+			"Pass: missing player",
+			`{"initCards": [[[6, "Drone"], [2, "Engineer"]]], "initResources": ["0"]}`,
+			false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var i InitInfo
+			if err := json.Unmarshal([]byte(tt.data), &i); err != nil {
+				t.Fatal(err)
+			}
+
+			if i.IsStandard() != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", i.IsStandard(), tt.exp)
+			}
+		})
+	}
+}
+
+func TestInitCardJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  InitCard
+		fail bool
+	}{
+		{"Pass: pair", `[6, "Drone"]`, InitCard{6, "Drone"}, false},
+		{"Error: reversed pair", `["Drone", 6]`, InitCard{}, true},
+		{"Error: missing count", `["Drone"]`, InitCard{}, true},
+		{"Error: not an array", `"Drone"`, InitCard{}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var c InitCard
+			err := json.Unmarshal([]byte(tt.data), &c)
+			assertError(t, err, tt.fail)
+
+			if !tt.fail && c != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", c, tt.exp)
+			}
+		})
+	}
+}
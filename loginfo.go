@@ -0,0 +1,79 @@
+package prismata
+
+import "encoding/json"
+
+// LogInfo contains information logged by the server about a Prismata replay.
+type LogInfo struct {
+	RawDeck RawDeck `json:"rawDeck"`
+}
+
+// RawDeck describes the game mode template a match was created from, such as
+// "Standard", before the random units of the match were chosen.
+type RawDeck struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// BaseCards lists the base set units available in every match.
+	BaseCards []string `json:"baseCards"`
+	// ExtraCards lists the special units the template allows outside of the
+	// random pool.
+	ExtraCards []string `json:"extraCards"`
+	// DominionCards is the pool the random units of a match are drawn from,
+	// grouped into slots.
+	DominionCards [][]string `json:"dominionCards"`
+	// DefaultEmbargo lists the units of the pool that cannot be drawn.
+	DefaultEmbargo []string `json:"defaultEmbargo"`
+
+	// The starting positions of the first (white) and second (black) players.
+	WhiteInitCards     []InitCard `json:"whiteInitCards"`
+	WhiteInitResources Resources  `json:"whiteInitResources"`
+	BlackInitCards     []InitCard `json:"blackInitCards"`
+	BlackInitResources Resources  `json:"blackInitResources"`
+
+	// SmallAmplifyArenaProbability and LargeAmplifyArenaProbability relate to
+	// amplified units in arena modes. The known replays only hold empty lists,
+	// so they are kept undecoded.
+	SmallAmplifyArenaProbability json.RawMessage `json:"smallAmplifyArenaProbability"`
+	LargeAmplifyArenaProbability json.RawMessage `json:"largeAmplifyArenaProbability"`
+}
+
+// IsEmbargoed reports whether the unit of the provided name is embargoed by
+// the template.
+func (d *RawDeck) IsEmbargoed(name string) bool {
+	for _, e := range d.DefaultEmbargo {
+		if e == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Eligible returns the units of the random pool that are not embargoed, in
+// pool order.
+func (d *RawDeck) Eligible() []string {
+	var names []string
+	for _, slot := range d.DominionCards {
+		for _, name := range slot {
+			if !d.IsEmbargoed(name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// Embargoed returns the units of the random pool that are embargoed, in pool
+// order.
+func (d *RawDeck) Embargoed() []string {
+	var names []string
+	for _, slot := range d.DominionCards {
+		for _, name := range slot {
+			if d.IsEmbargoed(name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
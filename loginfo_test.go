@@ -0,0 +1,136 @@
+package prismata
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLogInfo(t *testing.T) {
+	var cases = []struct {
+		name     string
+		file     string
+		eligible int
+	}{
+		{"Pass: replay 1", testFile1, 97},
+		{"Pass: replay 2", testFile2, 105},
+		{"Pass: replay 3", testFile3, 105},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+			d := r.LogInfo.RawDeck
+
+			if d.Name != "Standard" {
+				t.Errorf("got: <%v>, want: <%v>", d.Name, "Standard")
+			}
+
+			if d.Description != "The base set, plus random units." {
+				t.Errorf("got: <%v>, want: <%v>", d.Description, "The base set, plus random units.")
+			}
+
+			if len(d.BaseCards) != 11 {
+				t.Errorf("got: <%v>, want: <%v>", len(d.BaseCards), 11)
+			}
+
+			if len(d.ExtraCards) != 23 {
+				t.Errorf("got: <%v>, want: <%v>", len(d.ExtraCards), 23)
+			}
+
+			white := []InitCard{{6, "Drone"}, {2, "Engineer"}}
+			if !reflect.DeepEqual(d.WhiteInitCards, white) {
+				t.Errorf("got: <%v>, want: <%v>", d.WhiteInitCards, white)
+			}
+
+			black := []InitCard{{7, "Drone"}, {2, "Engineer"}}
+			if !reflect.DeepEqual(d.BlackInitCards, black) {
+				t.Errorf("got: <%v>, want: <%v>", d.BlackInitCards, black)
+			}
+
+			if !d.WhiteInitResources.IsZero() || !d.BlackInitResources.IsZero() {
+				t.Errorf("got: <%v, %v>, want: <0, 0>", d.WhiteInitResources, d.BlackInitResources)
+			}
+
+			if len(d.DefaultEmbargo) != 0 {
+				t.Errorf("got: <%v>, want: <%v>", len(d.DefaultEmbargo), 0)
+			}
+
+			if n := len(d.Eligible()); n != tt.eligible {
+				t.Errorf("got: <%v>, want: <%v>", n, tt.eligible)
+			}
+
+			adv, err := r.Deck.AdvancedSet()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			eligible := make(map[string]bool)
+			for _, name := range d.Eligible() {
+				eligible[name] = true
+			}
+
+			for _, name := range adv {
+				if !eligible[name] {
+					t.Errorf("got: <%v>, want: <eligible unit>", name)
+				}
+			}
+		})
+	}
+}
+
+func TestRawDeckAmplifyArena(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+	}{
+		{"Pass: empty", `{"smallAmplifyArenaProbability": [], "largeAmplifyArenaProbability": []}`},
+		{"Pass: numbers", `{"smallAmplifyArenaProbability": [0.5, 0.25]}`},
+		{"Pass: pairs", `{"largeAmplifyArenaProbability": [["Drake", 0.1]]}`},
+		{"Pass: object", `{"largeAmplifyArenaProbability": {"Drake": 0.1}}`},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var d RawDeck
+			if err := json.Unmarshal([]byte(tt.data), &d); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRawDeckEmbargo(t *testing.T) {
+	d := RawDeck{
+		DominionCards:  [][]string{{"Drake", "Perforator"}, {"Odin"}, {"Xaetron", "Husk"}},
+		DefaultEmbargo: []string{"Perforator", "Xaetron"},
+	}
+
+	var cases = []struct {
+		name string
+		unit string
+		exp  bool
+	}{
+		{"Pass: embargoed", "Perforator", true},
+		{"Pass: not embargoed", "Drake", false},
+		{"Pass: unknown", "Wall", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if d.IsEmbargoed(tt.unit) != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", d.IsEmbargoed(tt.unit), tt.exp)
+			}
+		})
+	}
+
+	eligible := []string{"Drake", "Odin", "Husk"}
+	if !reflect.DeepEqual(d.Eligible(), eligible) {
+		t.Errorf("got: <%v>, want: <%v>", d.Eligible(), eligible)
+	}
+
+	embargoed := []string{"Perforator", "Xaetron"}
+	if !reflect.DeepEqual(d.Embargoed(), embargoed) {
+		t.Errorf("got: <%v>, want: <%v>", d.Embargoed(), embargoed)
+	}
+}
@@ -1,11 +1,8 @@
 package prismata
 
 import (
-	"bytes"
-	"compress/gzip"
+	"context"
 	"io"
-	"io/ioutil"
-	"log"
 	"net/http"
 )
 
@@ -17,40 +14,20 @@ const (
 	extension   = ".json.gz"
 )
 
-func init() {
-	log.SetFlags(log.Llongfile)
-}
-
 // Get returns the replay corresponding to the provided code from the Prismata
-// AWS server.
+// AWS server. Get is a wrapper around the Get method of a zero-valued Client.
 func Get(code string) (*Replay, error) {
-	c := http.DefaultClient
-
-	req, err := request(code)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	resp, err := send(c, req)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	raw, err := unzip(resp.Body)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	replay, err := Decode(bytes.NewBuffer(raw))
-	if err != nil {
-		log.Fatal(err)
-	}
+	return GetContext(context.Background(), code)
+}
 
-	return replay, nil
+// GetContext is like Get but aborts the download and decoding of the replay
+// once the provided context is done.
+func GetContext(ctx context.Context, code string) (*Replay, error) {
+	return defaultClient.Get(ctx, code)
 }
 
-func request(code string) (*http.Request, error) {
-	req, err := http.NewRequest("GET", root+code+extension, nil)
+func request(url string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -69,27 +46,57 @@ func send(c *http.Client, req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
-func unbody(resp *http.Response) ([]byte, error) {
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+// ctxReader is an io.Reader that fails with the context's error once the
+// context is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
 	}
 
-	resp.Body.Close()
-	return b, nil
+	return r.r.Read(p)
 }
 
-func unzip(r io.Reader) ([]byte, error) {
-	zr, err := gzip.NewReader(r)
-	if err != nil {
-		return nil, err
+// countReader is an io.Reader that counts the bytes read through it.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// limitReader is an io.Reader that fails with ErrReplayTooLarge once more than
+// n bytes are read through it. Unlike io.LimitReader, exceeding the limit is
+// an error rather than the end of the stream.
+type limitReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (r *limitReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
 	}
-	defer zr.Close()
 
-	b, err := ioutil.ReadAll(zr)
-	if err != nil {
-		return nil, err
+	if int64(len(p)) > r.n+1 {
+		p = p[:r.n+1]
+	}
+
+	n, err := r.r.Read(p)
+	if int64(n) > r.n {
+		r.err = ErrReplayTooLarge
+		return 0, r.err
 	}
+	r.n -= int64(n)
 
-	return b, nil
+	return n, err
 }
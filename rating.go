@@ -0,0 +1,70 @@
+package prismata
+
+import (
+	"fmt"
+	"math"
+)
+
+// Tier represents a tier of the Prismata ranked ladder. Players climb from
+// Tier 1 to Tier 9 and reach Master, the highest tier, at Tier 10.
+type Tier int
+
+const (
+	// TierMin is the lowest tier of the ladder.
+	TierMin Tier = 1
+	// TierMaster is the highest tier of the ladder.
+	TierMaster Tier = 10
+)
+
+func (t Tier) String() string {
+	switch {
+	case t == TierMaster:
+		return "Master"
+	case t >= TierMin && t < TierMaster:
+		return fmt.Sprintf("Tier %d", int(t))
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(t))
+	}
+}
+
+// IsKnown reports whether the tier is part of the ladder.
+func (t Tier) IsKnown() bool {
+	return t >= TierMin && t <= TierMaster
+}
+
+// masterOffset is the offset between ShalevU and the display rating of
+// Master players.
+const masterOffset = 350
+
+// MasterRating returns the display rating of the player as computed for
+// Master players: ShalevU plus a fixed offset. It can be used to verify
+// DisplayRating for Master players only; below Master, the server displays a
+// lower value by an amount that replays do not record.
+func (r *Rating) MasterRating() float64 {
+	return r.ShalevU + masterOffset
+}
+
+// Stars returns the number of stars of the player, recorded as hStars.
+// Replays record stars for players of any tier.
+func (r *Rating) Stars() int {
+	return r.HStars
+}
+
+// Rank returns the rank of the player as displayed in game: the rounded
+// display rating for Master players, such as "Master 1813", and the tier and
+// progress towards the next tier otherwise, such as "Tier 9 (12%)". The
+// number of stars follows, if any, such as "Master 2001 ★85".
+func (r *Rating) Rank() string {
+	var rank string
+	if r.Tier == TierMaster {
+		rank = fmt.Sprintf("%v %d", r.Tier, int(math.Round(r.DisplayRating)))
+	} else {
+		rank = fmt.Sprintf("%v (%d%%)", r.Tier, int(r.TierPercent*100))
+	}
+
+	if n := r.Stars(); n > 0 {
+		rank += fmt.Sprintf(" ★%d", n)
+	}
+
+	return rank
+}
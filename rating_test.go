@@ -0,0 +1,87 @@
+package prismata
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTier(t *testing.T) {
+	var cases = []struct {
+		name  string
+		t     Tier
+		str   string
+		known bool
+	}{
+		{"Pass: lowest", TierMin, "Tier 1", true},
+		{"Pass: middle", Tier(8), "Tier 8", true},
+		{"Pass: master", TierMaster, "Master", true},
+		{"Pass: zero", Tier(0), "Unknown(0)", false},
+		{"Pass: above master", Tier(11), "Unknown(11)", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.t.String() != tt.str {
+				t.Errorf("got: <%v>, want: <%v>", tt.t.String(), tt.str)
+			}
+
+			if tt.t.IsKnown() != tt.known {
+				t.Errorf("got: <%v>, want: <%v>", tt.t.IsKnown(), tt.known)
+			}
+		})
+	}
+}
+
+func TestRatingRank(t *testing.T) {
+	var cases = []struct {
+		name   string
+		file   string
+		player int
+		rank   string
+		stars  int
+	}{
+		{"Pass: replay 1 player 1", testFile1, 0, "Tier 9 (21%)", 0},
+		{"Pass: replay 1 player 2", testFile1, 1, "Tier 8 (81%) ★26", 26},
+		{"Pass: replay 2 player 1", testFile2, 0, "Master 2136 ★10", 10},
+		{"Pass: replay 3 player 1", testFile3, 0, "Master 1813", 0},
+		{"Pass: replay 3 player 2", testFile3, 1, "Master 2001 ★85", 85},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			pr, err := r.RatingFor(tt.player)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rank := pr.Final.Rank(); rank != tt.rank {
+				t.Errorf("got: <%v>, want: <%v>", rank, tt.rank)
+			}
+
+			if s := pr.Final.Stars(); s != tt.stars {
+				t.Errorf("got: <%v>, want: <%v>", s, tt.stars)
+			}
+		})
+	}
+}
+
+func TestRatingMasterRating(t *testing.T) {
+	for _, file := range []string{testFile1, testFile2, testFile3} {
+		t.Run("Pass: "+file, func(t *testing.T) {
+			r := openReplay(t, file)
+
+			ri := r.RatingInfo
+			for _, rt := range append(ri.InitialRatings, ri.FinalRatings...) {
+				if rt.Tier != TierMaster {
+					continue
+				}
+
+				if m := rt.MasterRating(); math.Abs(m-rt.DisplayRating) > 1e-6 {
+					t.Errorf("got: <%v>, want: <%v>", m, rt.DisplayRating)
+				}
+			}
+		})
+	}
+}
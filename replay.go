@@ -3,7 +3,9 @@ package prismata
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -13,23 +15,19 @@ type Replay struct {
 	StartTimeUnix float64      `json:"startTime"`
 	EndTimeUnix   float64      `json:"endTime"`
 	Deck          Deck         `json:"deckInfo"`
+	InitInfo      InitInfo     `json:"initInfo"`
+	LogInfo       LogInfo      `json:"logInfo"`
 	PlayerInfo    []PlayerInfo `json:"playerInfo"`
-	// CommandInfo  CmdInfo    `json:"commandInfo"`
-	TimeInfo     TimeInfo   `json:"timeInfo"`
-	RatingInfo   RatingInfo `json:"ratingInfo"`
-	Result       Result     `json:"result"` // 0=p1, 1=p2, 2=draw
-	VersionInfo  Version    `json:"versionInfo"`
-	Seed         int        `json:"seed"`
-	EndCondition int        `json:"endCondition"`
-	Format       int        `json:"format"`
-	RawHash      int        `json:"rawHash"`
-}
-
-// Unit represents a single deployable unit of play.
-type Unit struct {
-	Name    string `json:"name"`
-	UIName  string `json:"UIName,omitempty"`
-	BaseSet int    `json:"baseSet,omitempty"`
+	CommandInfo   CmdInfo      `json:"commandInfo"`
+	ChatInfo      ChatInfo     `json:"chatInfo"`
+	TimeInfo      TimeInfo     `json:"timeInfo"`
+	RatingInfo    RatingInfo   `json:"ratingInfo"`
+	Result        Result       `json:"result"` // 0=p1, 1=p2, 2=draw
+	VersionInfo   Version      `json:"versionInfo"`
+	Seed          int          `json:"seed"`
+	EndCondition  EndCondition `json:"endCondition"`
+	Format        Format       `json:"format"`
+	RawHash       int          `json:"rawHash"`
 }
 
 // PlayerInfo contains information on a participating agent in a Prismata replay.
@@ -59,14 +57,63 @@ type CmdInfo struct {
 
 // Cmd represents a single command executed by a player.
 type Cmd struct {
-	Type   string `json:"_type"`
-	ID     int    `json:"_id"`
-	Params Emote  `json:"_params,omitempty"`
+	Type string `json:"_type"`
+	ID   int    `json:"_id"`
+	// Params holds the parameters of the command: an *Emote for emote
+	// commands, the raw JSON for other commands carrying parameters, and nil
+	// for commands without parameters.
+	Params interface{} `json:"_params,omitempty"`
+}
+
+// emotePrefix starts the type of every emote command. The rest of the type is
+// the text of the emote.
+const emotePrefix = "emote"
+
+// UnmarshalJSON decodes the command, decoding its parameters according to
+// its type.
+func (c *Cmd) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Type   string          `json:"_type"`
+		ID     int             `json:"_id"`
+		Params json.RawMessage `json:"_params"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	c.Type = raw.Type
+	c.ID = raw.ID
+	c.Params = nil
+
+	if strings.HasPrefix(raw.Type, emotePrefix) {
+		e := &Emote{Text: strings.TrimPrefix(raw.Type, emotePrefix)}
+		if len(raw.Params) > 0 && string(raw.Params) != "null" {
+			if err := json.Unmarshal(raw.Params, e); err != nil {
+				return err
+			}
+		}
+		c.Params = e
+		return nil
+	}
+
+	if len(raw.Params) > 0 && string(raw.Params) != "null" {
+		c.Params = raw.Params
+	}
+
+	return nil
+}
+
+// Emote returns the emote executed by the command, if it is an emote command.
+func (c *Cmd) Emote() (*Emote, bool) {
+	e, ok := c.Params.(*Emote)
+	return e, ok
 }
 
 // Emote contains information about a particular emote executed as a command
 // by a player.
 type Emote struct {
+	// Text is the message of the emote, taken from the command type.
+	Text           string `json:"-"`
 	MBackground    string `json:"mBackground"`
 	MTextAnimation string `json:"mTextAnimation"`
 	MColour        string `json:"mColour"`
@@ -118,7 +165,7 @@ type Rating struct {
 	PeakAdjustedShalevU float64 `json:"peakAdjustedShalevU"`
 	ShalevV             float64 `json:"shalevV"`
 	ShalevU             float64 `json:"shalevU"`
-	Tier                int     `json:"tier"`
+	Tier                Tier    `json:"tier"`
 	CustomGamesPlayed   int     `json:"customGamesPlayed"`
 	TierPercent         float64 `json:"tierPercent"`
 	CasualGamesWon      int     `json:"casualGamesWon"`
@@ -200,12 +247,46 @@ func (r *Replay) PlayerTwo() (*PlayerInfo, error) {
 	return &r.PlayerInfo[1], nil
 }
 
-// type PlayerRating struct {
-// 	Initial Rating
-// 	Final Rating
-// 	Delta []float64
-// 	ScoreDelta int
-// }
-// func (r *RatingInfo) PlayerOne() (*PlayerRating, error) {
+// PlayerRating contains information on the rating of a single player before
+// and after a Prismata match.
+type PlayerRating struct {
+	Initial Rating
+	Final   Rating
+	// Delta is the change to the player's rating: the change to ShalevU
+	// followed by the change to ShalevV.
+	Delta []float64
+	// ScoreDelta is the change to the player's score.
+	ScoreDelta int
+}
+
+// RatingFor returns the rating of the player of the provided index: 0 for
+// the first player and 1 for the second. It returns ErrUnrated if the replay
+// has no rating information.
+func (r *Replay) RatingFor(player int) (*PlayerRating, error) {
+	if player < 0 || player > 1 {
+		return nil, fmt.Errorf("invalid player index %d", player)
+	}
 
-// }
+	ri := &r.RatingInfo
+	if len(ri.InitialRatings) == 0 && len(ri.FinalRatings) == 0 && len(ri.RatingChanges) == 0 && len(ri.ScoreChanges) == 0 {
+		return nil, ErrUnrated
+	}
+
+	switch {
+	case player >= len(ri.InitialRatings):
+		return nil, fmt.Errorf("missing initial rating of player %d", player)
+	case player >= len(ri.FinalRatings):
+		return nil, fmt.Errorf("missing final rating of player %d", player)
+	case player >= len(ri.RatingChanges):
+		return nil, fmt.Errorf("missing rating change of player %d", player)
+	case player >= len(ri.ScoreChanges):
+		return nil, fmt.Errorf("missing score change of player %d", player)
+	}
+
+	return &PlayerRating{
+		Initial:    ri.InitialRatings[player],
+		Final:      ri.FinalRatings[player],
+		Delta:      ri.RatingChanges[player],
+		ScoreDelta: ri.ScoreChanges[player],
+	}, nil
+}
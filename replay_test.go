@@ -1,7 +1,12 @@
 package prismata
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -544,3 +549,229 @@ func TestPlayerTwo(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandInfo(t *testing.T) {
+	var cases = []struct {
+		name   string
+		file   string
+		cmds   int
+		turns  int
+		emotes int
+	}{
+		{"Pass: replay 1", testFile1, 287, 17, 0},
+		{"Pass: replay 2", testFile2, 480, 27, 7},
+		{"Pass: replay 3", testFile3, 958, 68, 6},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			r, err := Decode(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ci := r.CommandInfo
+			if len(ci.CommandList) != tt.cmds {
+				t.Errorf("got: <%v>, want: <%v>", len(ci.CommandList), tt.cmds)
+			}
+
+			if len(ci.CommandTimes) != tt.cmds {
+				t.Errorf("got: <%v>, want: <%v>", len(ci.CommandTimes), tt.cmds)
+			}
+
+			if len(ci.CommandForced) != tt.cmds {
+				t.Errorf("got: <%v>, want: <%v>", len(ci.CommandForced), tt.cmds)
+			}
+
+			if len(ci.ClicksPerTurn) != tt.turns {
+				t.Errorf("got: <%v>, want: <%v>", len(ci.ClicksPerTurn), tt.turns)
+			}
+
+			if len(ci.MoveDurations) != tt.turns+1 {
+				t.Errorf("got: <%v>, want: <%v>", len(ci.MoveDurations), tt.turns+1)
+			}
+
+			emotes := 0
+			for _, c := range ci.CommandList {
+				if _, ok := c.Emote(); ok {
+					emotes++
+				}
+			}
+
+			if emotes != tt.emotes {
+				t.Errorf("got: <%v>, want: <%v>", emotes, tt.emotes)
+			}
+		})
+	}
+}
+
+func TestCmdUnmarshalJSON(t *testing.T) {
+	var cases = []struct {
+		name   string
+		data   string
+		exp    Cmd
+		params interface{}
+	}{
+		{
+			"Pass: click without params",
+			`{"_type": "inst clicked", "_id": 12}`,
+			Cmd{Type: "inst clicked", ID: 12},
+			nil,
+		},
+		{
+			"Pass: styled emote",
+			`{"_type": "emoteGreetings, swarmwielder.", "_id": 0, "_params": {"mBackground": "bg_geometric", "mTextAnimation": "aerial", "mColour": "text_sapphire", "mTint": "tint_gold", "mFrame": "banded"}}`,
+			Cmd{Type: "emoteGreetings, swarmwielder.", ID: 0},
+			&Emote{
+				Text:           "Greetings, swarmwielder.",
+				MBackground:    "bg_geometric",
+				MTextAnimation: "aerial",
+				MColour:        "text_sapphire",
+				MTint:          "tint_gold",
+				MFrame:         "banded",
+			},
+		},
+		{
+			"Pass: plain emote",
+			`{"_type": "emoteGG!", "_id": 1, "_params": {}}`,
+			Cmd{Type: "emoteGG!", ID: 1},
+			&Emote{Text: "GG!"},
+		},
+		{
+			"Pass: other params",
+			`{"_type": "card clicked", "_id": 3, "_params": {"x": 1}}`,
+			Cmd{Type: "card clicked", ID: 3},
+			json.RawMessage(`{"x": 1}`),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Cmd
+			if err := json.Unmarshal([]byte(tt.data), &c); err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Type != tt.exp.Type {
+				t.Errorf("got: <%v>, want: <%v>", c.Type, tt.exp.Type)
+			}
+
+			if c.ID != tt.exp.ID {
+				t.Errorf("got: <%v>, want: <%v>", c.ID, tt.exp.ID)
+			}
+
+			if !reflect.DeepEqual(c.Params, tt.params) {
+				t.Errorf("got: <%#v>, want: <%#v>", c.Params, tt.params)
+			}
+		})
+	}
+}
+
+func TestRatingFor(t *testing.T) {
+	var cases = []struct {
+		name   string
+		file   string
+		player int
+		score  int
+		fail   bool
+	}{
+		{"Pass: replay 1 player 1", testFile1, 0, 408, false},
+		{"Pass: replay 1 player 2", testFile1, 1, 131, false},
+		{"Pass: replay 3 player 2", testFile3, 1, 276, false},
+		{"Error: negative index", testFile1, -1, 0, true},
+		{"Error: third player", testFile1, 2, 0, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			pr, err := r.RatingFor(tt.player)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if pr.ScoreDelta != tt.score {
+				t.Errorf("got: <%v>, want: <%v>", pr.ScoreDelta, tt.score)
+			}
+
+			if len(pr.Delta) != 2 {
+				t.Fatalf("got: <%v>, want: <%v>", len(pr.Delta), 2)
+			}
+
+			// The delta is the change to ShalevU followed by ShalevV.
+			du := pr.Final.ShalevU - pr.Initial.ShalevU
+			dv := pr.Final.ShalevV - pr.Initial.ShalevV
+			if math.Abs(du-pr.Delta[0]) > 1e-6 || math.Abs(dv-pr.Delta[1]) > 1e-6 {
+				t.Errorf("got: <%v>, want: <[%v %v]>", pr.Delta, du, dv)
+			}
+		})
+	}
+}
+
+func TestRatingForError(t *testing.T) {
+	var cases = []struct {
+		name    string
+		ri      RatingInfo
+		unrated bool
+	}{
+		{"Error: unrated", RatingInfo{}, true},
+		{"Error: missing final ratings", RatingInfo{InitialRatings: []Rating{{}, {}}}, false},
+		{
+			"Error: missing score changes",
+			RatingInfo{
+				InitialRatings: []Rating{{}, {}},
+				FinalRatings:   []Rating{{}, {}},
+				RatingChanges:  [][]float64{{0, 0}, {0, 0}},
+			},
+			false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Replay{RatingInfo: tt.ri}
+			_, err := r.RatingFor(0)
+			assertError(t, err, true)
+
+			if errors.Is(err, ErrUnrated) != tt.unrated {
+				t.Errorf("got: <%v>, want: <%v>", errors.Is(err, ErrUnrated), tt.unrated)
+			}
+		})
+	}
+}
+
+func TestReplayRoundTrip(t *testing.T) {
+	for _, file := range []string{testFile1, testFile2, testFile3} {
+		t.Run("Pass: "+file, func(t *testing.T) {
+			r := openReplay(t, file)
+
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			re, err := Decode(bytes.NewReader(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rb, err := json.Marshal(re)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(b, rb) {
+				t.Errorf("got: <%v bytes>, want: <%v bytes>", len(rb), len(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package prismata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Resources represents an amount of each resource in Prismata. In resource
+// notation, an amount is written as a gold number followed by one letter per
+// unit of every other resource: G for green, B for blue, C for red, H for
+// energy and A for attack. For example, "12BB" is 12 gold and 2 blue.
+type Resources struct {
+	// Gold is fractional only in unit scores, where the fraction breaks ties
+	// between units of equal value.
+	Gold   float64
+	Green  int
+	Blue   int
+	Red    int
+	Energy int
+	Attack int
+}
+
+// ParseResources parses an amount of resources in resource notation. The
+// empty string denotes no resources.
+func ParseResources(s string) (Resources, error) {
+	var r Resources
+
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+
+	if i > 0 {
+		g, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return Resources{}, fmt.Errorf("invalid gold in resources %q", s)
+		}
+		r.Gold = g
+	}
+
+	for _, ch := range s[i:] {
+		switch ch {
+		case 'G':
+			r.Green++
+		case 'B':
+			r.Blue++
+		case 'C':
+			r.Red++
+		case 'H':
+			r.Energy++
+		case 'A':
+			r.Attack++
+		default:
+			return Resources{}, fmt.Errorf("invalid resource %q in resources %q", ch, s)
+		}
+	}
+
+	return r, nil
+}
+
+// String returns the resources in resource notation. The gold number is
+// omitted when there is no gold but some other resource, and no resources at
+// all are written as "0". Negative amounts, as returned by Sub, cannot be
+// written in resource notation: negative gold keeps its sign and other
+// negative resources are omitted.
+func (r Resources) String() string {
+	var b strings.Builder
+	if r.Gold != 0 || r == (Resources{}) {
+		b.WriteString(strconv.FormatFloat(r.Gold, 'f', -1, 64))
+	}
+
+	b.WriteString(strings.Repeat("G", max0(r.Green)))
+	b.WriteString(strings.Repeat("B", max0(r.Blue)))
+	b.WriteString(strings.Repeat("C", max0(r.Red)))
+	b.WriteString(strings.Repeat("H", max0(r.Energy)))
+	b.WriteString(strings.Repeat("A", max0(r.Attack)))
+
+	return b.String()
+}
+
+// IsZero reports whether there are no resources.
+func (r Resources) IsZero() bool {
+	return r == Resources{}
+}
+
+// Add returns the sum of r and o.
+func (r Resources) Add(o Resources) Resources {
+	return Resources{
+		Gold:   r.Gold + o.Gold,
+		Green:  r.Green + o.Green,
+		Blue:   r.Blue + o.Blue,
+		Red:    r.Red + o.Red,
+		Energy: r.Energy + o.Energy,
+		Attack: r.Attack + o.Attack,
+	}
+}
+
+// Sub returns r minus o. Resources may become negative; use CanAfford to check
+// beforehand.
+func (r Resources) Sub(o Resources) Resources {
+	return Resources{
+		Gold:   r.Gold - o.Gold,
+		Green:  r.Green - o.Green,
+		Blue:   r.Blue - o.Blue,
+		Red:    r.Red - o.Red,
+		Energy: r.Energy - o.Energy,
+		Attack: r.Attack - o.Attack,
+	}
+}
+
+// CanAfford reports whether r holds at least the provided cost of every
+// resource.
+func (r Resources) CanAfford(cost Resources) bool {
+	return r.Gold >= cost.Gold &&
+		r.Green >= cost.Green &&
+		r.Blue >= cost.Blue &&
+		r.Red >= cost.Red &&
+		r.Energy >= cost.Energy &&
+		r.Attack >= cost.Attack
+}
+
+// MarshalJSON encodes the resources as a string in resource notation. It
+// returns an error for negative amounts, which the notation cannot express.
+func (r Resources) MarshalJSON() ([]byte, error) {
+	if r.isNegative() {
+		return nil, fmt.Errorf("cannot encode negative resources %+v", r)
+	}
+
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON decodes resources from a string in resource notation or from
+// a number of gold, both of which appear in replays.
+func (r *Resources) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var n float64
+		if err := json.Unmarshal(b, &n); err != nil {
+			return fmt.Errorf("invalid resources %s", b)
+		}
+		*r = Resources{Gold: n}
+		return nil
+	}
+
+	res, err := ParseResources(s)
+	if err != nil {
+		return err
+	}
+	*r = res
+
+	return nil
+}
+
+// isNegative reports whether any resource is negative.
+func (r Resources) isNegative() bool {
+	return r.Gold < 0 || r.Green < 0 || r.Blue < 0 || r.Red < 0 || r.Energy < 0 || r.Attack < 0
+}
+
+// max0 returns n, or 0 if n is negative.
+func max0(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
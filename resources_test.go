@@ -0,0 +1,185 @@
+package prismata
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseResources(t *testing.T) {
+	var cases = []struct {
+		name string
+		s    string
+		exp  Resources
+		str  string
+		fail bool
+	}{
+		{"Pass: gold and blue", "12BB", Resources{Gold: 12, Blue: 2}, "12BB", false},
+		{"Pass: gold and red", "6CC", Resources{Gold: 6, Red: 2}, "6CC", false},
+		{"Pass: every resource", "9GBBCHA", Resources{Gold: 9, Green: 1, Blue: 2, Red: 1, Energy: 1, Attack: 1}, "9GBBCHA", false},
+		{"Pass: gold only", "2", Resources{Gold: 2}, "2", false},
+		{"Pass: letters only", "AAA", Resources{Attack: 3}, "AAA", false},
+		{"Pass: unordered letters", "HGA", Resources{Green: 1, Energy: 1, Attack: 1}, "GHA", false},
+		{"Pass: fractional score", "2.01", Resources{Gold: 2.01}, "2.01", false},
+		{"Pass: zero", "0", Resources{}, "0", false},
+		{"Pass: empty", "", Resources{}, "0", false},
+		{"Error: lowercase", "12bb", Resources{}, "", true},
+		{"Error: unknown letter", "3X", Resources{}, "", true},
+		{"Error: gold after letters", "B2", Resources{}, "", true},
+		{"Error: malformed gold", "1.2.3", Resources{}, "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseResources(tt.s)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if r != tt.exp {
+				t.Errorf("got: <%+v>, want: <%+v>", r, tt.exp)
+			}
+
+			if r.String() != tt.str {
+				t.Errorf("got: <%v>, want: <%v>", r.String(), tt.str)
+			}
+		})
+	}
+}
+
+func TestResourcesArithmetic(t *testing.T) {
+	var cases = []struct {
+		name   string
+		r      Resources
+		o      Resources
+		sum    Resources
+		diff   Resources
+		afford bool
+	}{
+		{
+			"Pass: affordable",
+			Resources{Gold: 12, Blue: 3, Attack: 1},
+			Resources{Gold: 12, Blue: 2},
+			Resources{Gold: 24, Blue: 5, Attack: 1},
+			Resources{Blue: 1, Attack: 1},
+			true,
+		},
+		{
+			"Pass: missing gold",
+			Resources{Gold: 5, Red: 2},
+			Resources{Gold: 6, Red: 2},
+			Resources{Gold: 11, Red: 4},
+			Resources{Gold: -1},
+			false,
+		},
+		{
+			"Pass: missing energy",
+			Resources{Gold: 10},
+			Resources{Gold: 1, Energy: 1},
+			Resources{Gold: 11, Energy: 1},
+			Resources{Gold: 9, Energy: -1},
+			false,
+		},
+		{
+			"Pass: zero cost",
+			Resources{},
+			Resources{},
+			Resources{},
+			Resources{},
+			true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if s := tt.r.Add(tt.o); s != tt.sum {
+				t.Errorf("got: <%+v>, want: <%+v>", s, tt.sum)
+			}
+
+			if d := tt.r.Sub(tt.o); d != tt.diff {
+				t.Errorf("got: <%+v>, want: <%+v>", d, tt.diff)
+			}
+
+			if a := tt.r.CanAfford(tt.o); a != tt.afford {
+				t.Errorf("got: <%v>, want: <%v>", a, tt.afford)
+			}
+		})
+	}
+}
+
+func TestResourcesJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  Resources
+		fail bool
+	}{
+		{"Pass: string", `"12BB"`, Resources{Gold: 12, Blue: 2}, false},
+		{"Pass: number", `0`, Resources{}, false},
+		{"Pass: fractional number", `2.5`, Resources{Gold: 2.5}, false},
+		{"Error: invalid string", `"12X"`, Resources{}, true},
+		{"Error: object", `{"gold": 1}`, Resources{}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Resources
+			err := json.Unmarshal([]byte(tt.data), &r)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if r != tt.exp {
+				t.Errorf("got: <%+v>, want: <%+v>", r, tt.exp)
+			}
+
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var rt Resources
+			if err := json.Unmarshal(b, &rt); err != nil {
+				t.Fatal(err)
+			}
+
+			if rt != r {
+				t.Errorf("got: <%+v>, want: <%+v>", rt, r)
+			}
+		})
+	}
+}
+
+func TestResourcesMarshalNegative(t *testing.T) {
+	var cases = []struct {
+		name string
+		r    Resources
+		fail bool
+	}{
+		{"Pass: zero", Resources{}, false},
+		{"Pass: positive", Resources{Gold: 12, Blue: 2}, false},
+		{"Error: negative gold", Resources{Gold: -1}, true},
+		{"Error: negative blue", Resources{Gold: 1, Blue: -1}, true},
+		{"Error: subtraction", mustParseResources(t, "12BB").Sub(Resources{Gold: 13, Blue: 3}), true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := json.Marshal(tt.r)
+			assertError(t, err, tt.fail)
+		})
+	}
+}
+
+// mustParseResources parses the provided resources or fails the test.
+func mustParseResources(t *testing.T, s string) Resources {
+	r, err := ParseResources(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
@@ -1,12 +1,17 @@
 package prismata
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // Result represents the outcome of the match.
 type Result int
 
 const (
 	// P1 denotes a Player 1 win.
 	P1 Result = 0
-	// P2 deontes a Player 2 win.
+	// P2 denotes a Player 2 win.
 	P2 Result = 1
 	// Draw denotes a draw.
 	Draw Result = 2
@@ -21,6 +26,119 @@ func (r Result) String() string {
 	case 2:
 		return "Draw"
 	default:
-		return "Unknown"
+		return fmt.Sprintf("Unknown(%d)", int(r))
+	}
+}
+
+// PlayerIndex returns the index of the winning player: 0 for the first player
+// and 1 for the second. It returns ErrDraw for a draw.
+func (r Result) PlayerIndex() (int, error) {
+	switch r {
+	case P1:
+		return 0, nil
+	case P2:
+		return 1, nil
+	case Draw:
+		return 0, ErrDraw
+	default:
+		return 0, r.unknown()
+	}
+}
+
+// Opponent returns the result of the match from the perspective of the
+// losing player, i.e. P2 for P1 and P1 for P2. The opponent of a draw is a
+// draw.
+func (r Result) Opponent() (Result, error) {
+	switch r {
+	case P1:
+		return P2, nil
+	case P2:
+		return P1, nil
+	case Draw:
+		return Draw, nil
+	default:
+		return r, r.unknown()
+	}
+}
+
+// MarshalText encodes the result as "P1", "P2" or "Draw".
+func (r Result) MarshalText() ([]byte, error) {
+	switch r {
+	case P1, P2, Draw:
+		return []byte(r.String()), nil
+	default:
+		return nil, r.unknown()
+	}
+}
+
+// UnmarshalText decodes a result from "P1", "P2" or "Draw".
+func (r *Result) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "P1":
+		*r = P1
+	case "P2":
+		*r = P2
+	case "Draw":
+		*r = Draw
+	default:
+		return fmt.Errorf("unknown result %q", b)
 	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes a result from its name or, as found in replays, its
+// number. Numbers not known to this package are preserved.
+func (r *Result) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var n int
+		if err := json.Unmarshal(b, &n); err != nil {
+			return fmt.Errorf("invalid result %s", b)
+		}
+		*r = Result(n)
+		return nil
+	}
+
+	return r.UnmarshalText([]byte(s))
+}
+
+// unknown returns the error for a result not known to this package.
+func (r Result) unknown() error {
+	return fmt.Errorf("unknown result %d", int(r))
+}
+
+// Winner returns player info for the winning player. It returns ErrDraw if
+// the match ended in a draw.
+func (r *Replay) Winner() (*PlayerInfo, error) {
+	i, err := r.Result.PlayerIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.player(i)
+}
+
+// Loser returns player info for the losing player. It returns ErrDraw if the
+// match ended in a draw.
+func (r *Replay) Loser() (*PlayerInfo, error) {
+	i, err := r.Result.PlayerIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.player(1 - i)
+}
+
+// player returns player info for the player of the provided index.
+func (r *Replay) player(i int) (*PlayerInfo, error) {
+	if i == 0 {
+		return r.PlayerOne()
+	}
+
+	return r.PlayerTwo()
 }
@@ -0,0 +1,130 @@
+package prismata
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	var cases = []struct {
+		name  string
+		r     Result
+		str   string
+		index int
+		opp   Result
+		fail  bool
+	}{
+		{"Pass: P1", P1, "P1", 0, P2, false},
+		{"Pass: P2", P2, "P2", 1, P1, false},
+		{"Error: draw", Draw, "Draw", 0, Draw, true},
+		{"Error: unknown", Result(5), "Unknown(5)", 0, Result(5), true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.r.String() != tt.str {
+				t.Errorf("got: <%v>, want: <%v>", tt.r.String(), tt.str)
+			}
+
+			i, err := tt.r.PlayerIndex()
+			assertError(t, err, tt.fail)
+
+			if i != tt.index {
+				t.Errorf("got: <%v>, want: <%v>", i, tt.index)
+			}
+
+			if (tt.r == Draw) != errors.Is(err, ErrDraw) {
+				t.Errorf("got: <%v>, want: <%v>", err, ErrDraw)
+			}
+
+			opp, err := tt.r.Opponent()
+			assertError(t, err, tt.r != P1 && tt.r != P2 && tt.r != Draw)
+
+			if opp != tt.opp {
+				t.Errorf("got: <%v>, want: <%v>", opp, tt.opp)
+			}
+		})
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  Result
+		out  string
+		fail bool
+	}{
+		{"Pass: number", `0`, P1, `"P1"`, false},
+		{"Pass: name", `"P2"`, P2, `"P2"`, false},
+		{"Pass: draw", `"Draw"`, Draw, `"Draw"`, false},
+		{"Pass: unknown number", `7`, Result(7), ``, false},
+		{"Error: unknown name", `"P3"`, P1, ``, true},
+		{"Error: invalid", `true`, P1, ``, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Result
+			err := json.Unmarshal([]byte(tt.data), &r)
+			assertError(t, err, tt.fail)
+
+			if r != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", r, tt.exp)
+			}
+
+			if tt.fail {
+				return
+			}
+
+			b, err := json.Marshal(r)
+			assertError(t, err, tt.out == "")
+
+			if string(b) != tt.out {
+				t.Errorf("got: <%v>, want: <%v>", string(b), tt.out)
+			}
+		})
+	}
+}
+
+func TestReplayWinner(t *testing.T) {
+	var cases = []struct {
+		name   string
+		file   string
+		winner string
+		loser  string
+		fail   bool
+	}{
+		{"Pass: replay 1", testFile1, "Lifecoach", "NekoNoire", false},
+		{"Pass: replay 2", testFile2, "Yujiri", "meoweth", false},
+		{"Error: replay 3 draw", testFile3, "", "", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			w, err := r.Winner()
+			assertError(t, err, tt.fail)
+
+			l, lerr := r.Loser()
+			assertError(t, lerr, tt.fail)
+
+			if tt.fail {
+				if !errors.Is(err, ErrDraw) || !errors.Is(lerr, ErrDraw) {
+					t.Errorf("got: <%v, %v>, want: <%v>", err, lerr, ErrDraw)
+				}
+				return
+			}
+
+			if w.Name != tt.winner {
+				t.Errorf("got: <%v>, want: <%v>", w.Name, tt.winner)
+			}
+
+			if l.Name != tt.loser {
+				t.Errorf("got: <%v>, want: <%v>", l.Name, tt.loser)
+			}
+		})
+	}
+}
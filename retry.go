@@ -0,0 +1,158 @@
+package prismata
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultBaseDelay = time.Millisecond * 500
+	defaultMaxDelay  = time.Second * 30
+)
+
+// RetryPolicy describes how a Client retries failed downloads. Delays between
+// attempts grow exponentially from BaseDelay up to MaxDelay with full jitter,
+// unless the server requests a specific delay through a Retry-After header.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the first.
+	// Values below 1 are treated as 1.
+	MaxAttempts int
+	// BaseDelay is the upper bound of the delay before the second attempt.
+	// Defaults to 500ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between any two attempts, including delays
+	// requested by the server. Defaults to 30s.
+	MaxDelay time.Duration
+	// RetryOn reports whether a response with the given status code should be
+	// retried. Defaults to RetryableStatus.
+	RetryOn func(status int) bool
+	// OnAttempt, if set, is called after every attempt.
+	OnAttempt func(Attempt)
+}
+
+// Attempt describes the outcome of a single download attempt.
+type Attempt struct {
+	// Code is the replay code being downloaded.
+	Code string
+	// Number is the attempt number, starting at 1.
+	Number int
+	// StatusCode is the status of a rejected response, or zero if the attempt
+	// succeeded or failed without a response.
+	StatusCode int
+	// Err is the error of the attempt, or nil if it succeeded.
+	Err error
+	// Delay is the wait before the next attempt, or zero if no further attempt
+	// will be made.
+	Delay time.Duration
+}
+
+// RetryableStatus reports whether the status code denotes a transient server
+// failure: 429 Too Many Requests or any 5xx other than 501 Not Implemented.
+func RetryableStatus(status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+
+	return status >= 500 && status != http.StatusNotImplemented
+}
+
+// retry reports whether another attempt should follow failed attempt n.
+func (p *RetryPolicy) retry(n int, err error) bool {
+	if p == nil || n >= p.MaxAttempts {
+		return false
+	}
+
+	var se *HTTPStatusError
+	if errors.As(err, &se) {
+		if p.RetryOn != nil {
+			return p.RetryOn(se.StatusCode)
+		}
+		return RetryableStatus(se.StatusCode)
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var ne net.Error
+	return errors.As(err, &ne) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// delay returns the wait after failed attempt n.
+func (p *RetryPolicy) delay(n int, err error) time.Duration {
+	max := p.MaxDelay
+	if max <= 0 {
+		max = defaultMaxDelay
+	}
+
+	var se *HTTPStatusError
+	if errors.As(err, &se) && se.RetryAfter > 0 {
+		if se.RetryAfter > max {
+			return max
+		}
+		return se.RetryAfter
+	}
+
+	base := p.BaseDelay
+	if base <= 0 {
+		base = defaultBaseDelay
+	}
+
+	d := base
+	for i := 1; i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// observe reports the attempt to the policy's hook, if any.
+func (p *RetryPolicy) observe(a Attempt) {
+	if p != nil && p.OnAttempt != nil {
+		p.OnAttempt(a)
+	}
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, relative to now.
+func retryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0
+		}
+		return time.Duration(s) * time.Second
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil || !t.After(now) {
+		return 0
+	}
+
+	return t.Sub(now)
+}
+
+// sleep waits for the given duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
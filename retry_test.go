@@ -0,0 +1,232 @@
+package prismata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// flakyServer returns a server that responds with the given status to the
+// first n requests and serves the testdata directory afterwards.
+func flakyServer(n int, status int, header http.Header) *httptest.Server {
+	fs := http.FileServer(http.Dir("testdata"))
+	count := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		fs.ServeHTTP(w, r)
+	}))
+}
+
+func TestClientGetRetry(t *testing.T) {
+	var cases = []struct {
+		name     string
+		failures int
+		status   int
+		header   http.Header
+		policy   *RetryPolicy
+		attempts int
+		fail     bool
+	}{
+		{
+			"Pass: no failures",
+			0,
+			http.StatusServiceUnavailable,
+			nil,
+			&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			1,
+			false,
+		},
+		{
+			"Pass: recovers after two failures",
+			2,
+			http.StatusServiceUnavailable,
+			nil,
+			&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			3,
+			false,
+		},
+		{
+			"Pass: custom predicate",
+			1,
+			http.StatusForbidden,
+			nil,
+			&RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, RetryOn: func(int) bool { return true }},
+			2,
+			false,
+		},
+		{
+			"Error: attempts exhausted",
+			3,
+			http.StatusBadGateway,
+			nil,
+			&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			3,
+			true,
+		},
+		{
+			"Error: status not retryable",
+			1,
+			http.StatusNotFound,
+			nil,
+			&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			1,
+			true,
+		},
+		{
+			"Error: nil policy",
+			1,
+			http.StatusServiceUnavailable,
+			nil,
+			nil,
+			0,
+			true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := flakyServer(tt.failures, tt.status, tt.header)
+			defer ts.Close()
+
+			var attempts []Attempt
+			if tt.policy != nil {
+				tt.policy.OnAttempt = func(a Attempt) { attempts = append(attempts, a) }
+			}
+
+			c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client(), Retry: tt.policy}
+			_, err := c.Get(context.Background(), replaycode1)
+			assertError(t, err, tt.fail)
+
+			if len(attempts) != tt.attempts {
+				t.Fatalf("got: <%v>, want: <%v>", len(attempts), tt.attempts)
+			}
+
+			for i, a := range attempts {
+				if a.Number != i+1 {
+					t.Errorf("got: <%v>, want: <%v>", a.Number, i+1)
+				}
+
+				last := i == len(attempts)-1
+				if !last && a.StatusCode != tt.status {
+					t.Errorf("got: <%v>, want: <%v>", a.StatusCode, tt.status)
+				}
+
+				if last && a.Delay != 0 {
+					t.Errorf("got: <%v>, want: <%v>", a.Delay, 0)
+				}
+			}
+		})
+	}
+}
+
+func TestClientGetRetryConnReset(t *testing.T) {
+	fs := http.FileServer(http.Dir("testdata"))
+	count := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		fs.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	p := &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client(), Retry: p}
+	_, err := c.Get(context.Background(), replaycode1)
+	assertError(t, err, false)
+
+	if count != 2 {
+		t.Errorf("got: <%v>, want: <%v>", count, 2)
+	}
+}
+
+func TestClientGetRetryAfter(t *testing.T) {
+	ts := flakyServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}})
+	defer ts.Close()
+
+	var delays []time.Duration
+	p := &RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond * 20,
+		OnAttempt:   func(a Attempt) { delays = append(delays, a.Delay) },
+	}
+
+	c := &Client{BaseURL: ts.URL, HTTPClient: ts.Client(), Retry: p}
+	_, err := c.Get(context.Background(), replaycode1)
+	assertError(t, err, false)
+
+	if len(delays) != 2 {
+		t.Fatalf("got: <%v>, want: <%v>", len(delays), 2)
+	}
+
+	if delays[0] != p.MaxDelay {
+		t.Errorf("got: <%v>, want: <%v>", delays[0], p.MaxDelay)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2018, time.August, 8, 12, 0, 0, 0, time.UTC)
+	var cases = []struct {
+		name string
+		v    string
+		exp  time.Duration
+	}{
+		{"Pass: seconds", "120", time.Minute * 2},
+		{"Pass: http date", now.Add(time.Second * 30).Format(http.TimeFormat), time.Second * 30},
+		{"Pass: past http date", now.Add(-time.Second * 30).Format(http.TimeFormat), 0},
+		{"Pass: negative seconds", "-5", 0},
+		{"Pass: empty", "", 0},
+		{"Pass: invalid", "soon", 0},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := retryAfter(tt.v, now)
+			if d != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", d, tt.exp)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Millisecond * 10, MaxDelay: time.Millisecond * 50}
+	var cases = []struct {
+		name string
+		n    int
+		max  time.Duration
+	}{
+		{"Pass: first retry", 1, time.Millisecond * 10},
+		{"Pass: second retry", 2, time.Millisecond * 20},
+		{"Pass: third retry", 3, time.Millisecond * 40},
+		{"Pass: capped", 10, time.Millisecond * 50},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				d := p.delay(tt.n, nil)
+				if d < 0 || d > tt.max {
+					t.Fatalf("got: <%v>, want: <[0, %v]>", d, tt.max)
+				}
+			}
+		})
+	}
+}
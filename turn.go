@@ -0,0 +1,96 @@
+package prismata
+
+import (
+	"errors"
+	"time"
+)
+
+// Turn represents a single turn of a Prismata match.
+type Turn struct {
+	// Number is the number of the turn, starting at 1.
+	Number int
+	// Player is the index of the player taking the turn: 0 for the first
+	// player and 1 for the second.
+	Player int
+	// Commands are the commands executed during the turn.
+	Commands []Cmd
+	// Start and End are the offsets of the beginning and end of the turn from
+	// the start of the match.
+	Start time.Duration
+	End   time.Duration
+	// Duration is the time taken by the turn.
+	Duration time.Duration
+	// TimeBank is the time remaining in the player's time bank at the end of
+	// the turn.
+	TimeBank time.Duration
+	// Forced reports whether any command of the turn was forced by the game,
+	// such as when the player ran out of time.
+	Forced bool
+}
+
+// Turns splits the command stream of the replay into turns.
+//
+// Commands sent before the first turn, such as opening emotes, are not
+// counted as part of any turn by the replay and are included in the first
+// turn.
+func (r *Replay) Turns() ([]Turn, error) {
+	ci := &r.CommandInfo
+
+	total := 0
+	for _, n := range ci.ClicksPerTurn {
+		if n < 0 {
+			return nil, errors.New("negative click count")
+		}
+		total += n
+	}
+
+	lead := len(ci.CommandList) - total
+	if lead < 0 {
+		return nil, errors.New("click counts exceed command list")
+	}
+
+	turns := make([]Turn, len(ci.ClicksPerTurn))
+	var start time.Duration
+	i := 0
+	for t, n := range ci.ClicksPerTurn {
+		if t == 0 {
+			n += lead
+		}
+
+		turn := Turn{
+			Number:   t + 1,
+			Player:   t % 2,
+			Commands: ci.CommandList[i : i+n],
+			Start:    start,
+		}
+
+		// The first move duration precedes the first turn.
+		if t+1 < len(ci.MoveDurations) {
+			turn.Duration = seconds(ci.MoveDurations[t+1])
+		}
+		turn.End = turn.Start + turn.Duration
+
+		// The time banks of both players are listed before the first turn.
+		if t+2 < len(ci.TimeBanksRemaining) {
+			turn.TimeBank = seconds(ci.TimeBanksRemaining[t+2])
+		}
+
+		for j := i; j < i+n && j < len(ci.CommandForced); j++ {
+			if ci.CommandForced[j] {
+				turn.Forced = true
+				break
+			}
+		}
+
+		turns[t] = turn
+		start = turn.End
+		i += n
+	}
+
+	return turns, nil
+}
+
+// seconds converts a number of seconds to a time.Duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,133 @@
+package prismata
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// openReplay decodes the replay in the provided file or fails the test.
+func openReplay(t *testing.T, file string) *Replay {
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	r, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestTurns(t *testing.T) {
+	var cases = []struct {
+		name     string
+		file     string
+		turns    int
+		first    int
+		last     int
+		forced   bool
+		duration time.Duration
+		bank     time.Duration
+	}{
+		{"Pass: replay 1", testFile1, 17, 5, 9, false, seconds(18.10308289527893), seconds(70.47422927618027)},
+		{"Pass: replay 2", testFile2, 27, 8, 24, true, seconds(4.409416198730469), seconds(36.39764595031738)},
+		{"Pass: replay 3", testFile3, 68, 4, 0, false, seconds(1.8127188682556152), seconds(24.546820282936096)},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			turns, err := r.Turns()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(turns) != tt.turns {
+				t.Fatalf("got: <%v>, want: <%v>", len(turns), tt.turns)
+			}
+
+			first := turns[0]
+			if len(first.Commands) != tt.first {
+				t.Errorf("got: <%v>, want: <%v>", len(first.Commands), tt.first)
+			}
+
+			if first.Forced != tt.forced {
+				t.Errorf("got: <%v>, want: <%v>", first.Forced, tt.forced)
+			}
+
+			if first.Duration != tt.duration {
+				t.Errorf("got: <%v>, want: <%v>", first.Duration, tt.duration)
+			}
+
+			if first.TimeBank != tt.bank {
+				t.Errorf("got: <%v>, want: <%v>", first.TimeBank, tt.bank)
+			}
+
+			if last := turns[len(turns)-1]; len(last.Commands) != tt.last {
+				t.Errorf("got: <%v>, want: <%v>", len(last.Commands), tt.last)
+			}
+
+			cmds := 0
+			for i, turn := range turns {
+				cmds += len(turn.Commands)
+
+				if turn.Number != i+1 {
+					t.Errorf("got: <%v>, want: <%v>", turn.Number, i+1)
+				}
+
+				if turn.Player != i%2 {
+					t.Errorf("got: <%v>, want: <%v>", turn.Player, i%2)
+				}
+
+				if i > 0 && turn.Start != turns[i-1].End {
+					t.Errorf("got: <%v>, want: <%v>", turn.Start, turns[i-1].End)
+				}
+
+				if turn.End-turn.Start != turn.Duration {
+					t.Errorf("got: <%v>, want: <%v>", turn.End-turn.Start, turn.Duration)
+				}
+			}
+
+			if cmds != len(r.CommandInfo.CommandList) {
+				t.Errorf("got: <%v>, want: <%v>", cmds, len(r.CommandInfo.CommandList))
+			}
+		})
+	}
+}
+
+func TestTurnsError(t *testing.T) {
+	var cases = []struct {
+		name string
+		ci   CmdInfo
+		fail bool
+	}{
+		{
+			"Pass: empty",
+			CmdInfo{},
+			false,
+		},
+		{
+			"Error: click counts exceed commands",
+			CmdInfo{CommandList: []Cmd{{}, {}}, ClicksPerTurn: []int{2, 1}},
+			true,
+		},
+		{
+			"Error: negative click count",
+			CmdInfo{CommandList: []Cmd{{}, {}}, ClicksPerTurn: []int{3, -1}},
+			true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Replay{CommandInfo: tt.ci}
+			_, err := r.Turns()
+			assertError(t, err, tt.fail)
+		})
+	}
+}
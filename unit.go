@@ -0,0 +1,303 @@
+package prismata
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Unit represents a single deployable unit of play.
+type Unit struct {
+	Name        string `json:"name"`
+	UIName      string `json:"UIName,omitempty"`
+	UIShortname string `json:"UIShortname,omitempty"`
+	BaseSet     int    `json:"baseSet,omitempty"`
+	Rarity      Rarity `json:"rarity,omitempty"`
+	Group       string `json:"group,omitempty"`
+	// Description holds the lines of the unit's in-game description, if the
+	// description is not generated from the unit's properties.
+	Description []string `json:"fullDescription,omitempty"`
+
+	// BuyCost is the cost of buying the unit.
+	BuyCost Resources `json:"buyCost"`
+	// BuySac lists the units sacrificed when buying the unit.
+	BuySac []Sacrifice `json:"buySac,omitempty"`
+	// BuyScript runs when the unit is bought.
+	BuyScript *Script `json:"buyScript,omitempty"`
+	// BuildTime is the number of turns before the unit is ready after being
+	// bought, 0 for units that are ready at once. Replays omit the build time
+	// of units taking a single turn.
+	BuildTime int `json:"buildTime"`
+	// Needs lists the other units the unit depends on, such as the units it
+	// creates or sacrifices.
+	Needs []string `json:"needs,omitempty"`
+
+	Toughness int `json:"toughness"`
+	// Lifespan is the number of turns the unit lasts, or zero if it does not
+	// expire.
+	Lifespan int `json:"lifespan,omitempty"`
+	// Charge is the number of times the unit's ability can be used, or zero
+	// if it is unlimited.
+	Charge          int  `json:"charge,omitempty"`
+	Fragile         bool `json:"fragile,omitempty"`
+	Undefendable    bool `json:"undefendable,omitempty"`
+	DefaultBlocking bool `json:"defaultBlocking,omitempty"`
+	// AssignedBlocking is the blocking state the unit is given when assigned
+	// by the player.
+	AssignedBlocking int `json:"assignedBlocking,omitempty"`
+	// HPGained, HPMax and HPUsed describe units that regain health, such as
+	// those that consume charges to heal.
+	HPGained int `json:"HPGained,omitempty"`
+	HPMax    int `json:"HPMax,omitempty"`
+	HPUsed   int `json:"HPUsed,omitempty"`
+
+	// AbilityCost is the cost of using the unit's ability.
+	AbilityCost Resources `json:"abilityCost"`
+	// AbilitySac lists the units sacrificed when using the unit's ability.
+	AbilitySac []Sacrifice `json:"abilitySac,omitempty"`
+	// AbilityScript runs when the unit's ability is used.
+	AbilityScript *Script `json:"abilityScript,omitempty"`
+	// TargetAction and TargetAmount describe abilities that target enemy
+	// units, such as "disrupt".
+	TargetAction string `json:"targetAction,omitempty"`
+	TargetAmount int    `json:"targetAmount,omitempty"`
+
+	// BeginOwnTurnScript runs at the start of each of its owner's turns.
+	BeginOwnTurnScript *Script `json:"beginOwnTurnScript,omitempty"`
+
+	// Score is the value of the unit used by the game's scoring.
+	Score Resources `json:"score"`
+}
+
+// DisplayName returns the name of the unit as shown in the game.
+func (u *Unit) DisplayName() string {
+	if u.UIName != "" {
+		return u.UIName
+	}
+	return u.Name
+}
+
+// IsBase reports whether the unit is part of the base set available in every
+// game, as opposed to the random set of the game.
+func (u *Unit) IsBase() bool {
+	return u.BaseSet != 0
+}
+
+// defaultBuildTime is the build time of units whose build time is omitted.
+const defaultBuildTime = 1
+
+// UnmarshalJSON decodes the unit, converting the numeric flags used by the
+// replay format and applying the default build time.
+func (u *Unit) UnmarshalJSON(b []byte) error {
+	type unit Unit
+	aux := struct {
+		*unit
+		Fragile         flag `json:"fragile"`
+		Undefendable    flag `json:"undefendable"`
+		DefaultBlocking flag `json:"defaultBlocking"`
+		BuildTime       *int `json:"buildTime"`
+	}{unit: (*unit)(u)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	u.Fragile = bool(aux.Fragile)
+	u.Undefendable = bool(aux.Undefendable)
+	u.DefaultBlocking = bool(aux.DefaultBlocking)
+
+	u.BuildTime = defaultBuildTime
+	if aux.BuildTime != nil {
+		u.BuildTime = *aux.BuildTime
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the unit, omitting the build time of units taking a
+// single turn to build as replays do.
+func (u Unit) MarshalJSON() ([]byte, error) {
+	type unit Unit
+	aux := struct {
+		unit
+		BuildTime *int `json:"buildTime,omitempty"`
+	}{unit: unit(u)}
+
+	if u.BuildTime != defaultBuildTime {
+		aux.BuildTime = &u.BuildTime
+	}
+
+	return json.Marshal(aux)
+}
+
+// flag is a boolean encoded either as a number, as in replays, or as a JSON
+// bool, as written by Unit.
+type flag bool
+
+// UnmarshalJSON decodes the flag from a number or a bool.
+func (f *flag) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case nil:
+	case bool:
+		*f = flag(v)
+	case float64:
+		*f = v != 0
+	default:
+		return fmt.Errorf("invalid flag %s", b)
+	}
+
+	return nil
+}
+
+// Rarity is the rarity of a unit, which determines its default supply.
+type Rarity string
+
+const (
+	// RarityTrinket denotes cheap units available in large numbers.
+	RarityTrinket Rarity = "trinket"
+	// RarityNormal denotes the most common units.
+	RarityNormal Rarity = "normal"
+	// RarityRare denotes units of limited supply.
+	RarityRare Rarity = "rare"
+	// RarityLegendary denotes units of which a single copy is available.
+	RarityLegendary Rarity = "legendary"
+)
+
+// Script describes the effects of a unit when bought, when its ability is
+// used or at the start of its owner's turn.
+type Script struct {
+	// Receive is the resources gained.
+	Receive Resources `json:"receive"`
+	// Create lists the units constructed.
+	Create []Creation `json:"create,omitempty"`
+	// SelfSac reports whether the unit sacrifices itself.
+	SelfSac bool `json:"selfsac,omitempty"`
+	// Delay is the number of turns the unit stays exhausted after the script
+	// runs.
+	Delay int `json:"delay,omitempty"`
+}
+
+// Creation describes units constructed by a script.
+type Creation struct {
+	Name string
+	// Opponent reports whether the units are constructed for the opponent
+	// rather than for the unit's owner.
+	Opponent bool
+	Count    int
+	// BuildTime is the number of turns before the units are ready, or -1 if
+	// the units' own build time applies.
+	BuildTime int
+}
+
+// UnmarshalJSON decodes a creation from its array form: the unit name, the
+// owner ("own" or "opponent"), and optionally the count and the build time.
+func (c *Creation) UnmarshalJSON(b []byte) error {
+	var raw []interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if len(raw) < 2 || len(raw) > 4 {
+		return fmt.Errorf("invalid creation %s", b)
+	}
+
+	name, ok := raw[0].(string)
+	if !ok {
+		return fmt.Errorf("invalid creation name %v", raw[0])
+	}
+
+	owner, ok := raw[1].(string)
+	if !ok || (owner != "own" && owner != "opponent") {
+		return fmt.Errorf("invalid creation owner %v", raw[1])
+	}
+
+	*c = Creation{Name: name, Opponent: owner == "opponent", Count: 1, BuildTime: -1}
+
+	if len(raw) > 2 {
+		n, err := count(raw[2])
+		if err != nil {
+			return err
+		}
+		c.Count = n
+	}
+
+	if len(raw) > 3 {
+		n, err := count(raw[3])
+		if err != nil {
+			return err
+		}
+		c.BuildTime = n
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the creation in its array form.
+func (c Creation) MarshalJSON() ([]byte, error) {
+	owner := "own"
+	if c.Opponent {
+		owner = "opponent"
+	}
+
+	raw := []interface{}{c.Name, owner, c.Count}
+	if c.BuildTime >= 0 {
+		raw = append(raw, c.BuildTime)
+	}
+
+	return json.Marshal(raw)
+}
+
+// Sacrifice describes units consumed by buying a unit or using its ability.
+type Sacrifice struct {
+	Name  string
+	Count int
+}
+
+// UnmarshalJSON decodes a sacrifice from its array form: the unit name and
+// optionally the count.
+func (s *Sacrifice) UnmarshalJSON(b []byte) error {
+	var raw []interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if len(raw) < 1 || len(raw) > 2 {
+		return fmt.Errorf("invalid sacrifice %s", b)
+	}
+
+	name, ok := raw[0].(string)
+	if !ok {
+		return fmt.Errorf("invalid sacrifice name %v", raw[0])
+	}
+
+	*s = Sacrifice{Name: name, Count: 1}
+
+	if len(raw) > 1 {
+		n, err := count(raw[1])
+		if err != nil {
+			return err
+		}
+		s.Count = n
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the sacrifice in its array form.
+func (s Sacrifice) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{s.Name, s.Count})
+}
+
+// count converts a decoded JSON number to a non-negative int.
+func count(v interface{}) (int, error) {
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != float64(int(f)) {
+		return 0, fmt.Errorf("invalid count %v", v)
+	}
+
+	return int(f), nil
+}
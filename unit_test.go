@@ -0,0 +1,326 @@
+package prismata
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// findUnit returns the unit of the provided name in the deck or fails the test.
+func findUnit(t *testing.T, d *Deck, name string) Unit {
+	for _, u := range d.MergedDeck {
+		if u.Name == name {
+			return u
+		}
+	}
+
+	t.Fatalf("got: <nil>, want: <%v>", name)
+	return Unit{}
+}
+
+func TestUnitDecode(t *testing.T) {
+	var cases = []struct {
+		name string
+		file string
+		exp  Unit
+	}{
+		{
+			"Pass: Drake",
+			testFile1,
+			Unit{
+				Name:               "Drake",
+				Rarity:             "rare",
+				BuyCost:            Resources{Gold: 12, Blue: 2},
+				BuildTime:          1,
+				Needs:              []string{"Blastforge"},
+				Toughness:          4,
+				AssignedBlocking:   0,
+				AbilitySac:         []Sacrifice{{"Blastforge", 1}},
+				AbilityScript:      &Script{Receive: Resources{Attack: 2}},
+				BeginOwnTurnScript: &Script{Receive: Resources{Attack: 2}},
+			},
+		},
+		{
+			"Pass: Xaetron",
+			testFile1,
+			Unit{
+				Name:            "Xaetron",
+				Rarity:          "legendary",
+				BuyCost:         Resources{Gold: 11, Green: 5},
+				Needs:           []string{"Gauss Charge"},
+				Toughness:       4,
+				Fragile:         true,
+				DefaultBlocking: true,
+				HPGained:        4,
+				HPMax:           12,
+				HPUsed:          7,
+				AbilityScript:   &Script{Create: []Creation{{"Gauss Charge", false, 5, -1}}},
+			},
+		},
+		{
+			"Pass: The Wincer",
+			testFile1,
+			Unit{
+				Name:          "The Wincer",
+				UIName:        "The Wincer",
+				UIShortname:   "Wincer",
+				Rarity:        "legendary",
+				BuyCost:       Resources{Gold: 9, Green: 1, Blue: 2, Red: 1},
+				BuildTime:     3,
+				Needs:         []string{"Drone"},
+				Toughness:     5,
+				Fragile:       true,
+				AbilitySac:    []Sacrifice{{"Drone", 5}},
+				AbilityScript: &Script{Receive: Resources{Attack: 15}, Delay: 3},
+				Score:         Resources{Gold: 30},
+			},
+		},
+		{
+			"Pass: Redeemer",
+			testFile2,
+			Unit{
+				Name:            "Redeemer",
+				UIName:          "Redeemer",
+				Rarity:          "rare",
+				BuyCost:         Resources{Gold: 10, Green: 1, Blue: 1},
+				BuyScript:       &Script{Create: []Creation{{"Gauss Charge", true, 6, 3}}},
+				BuildTime:       1,
+				Needs:           []string{"Gauss Charge"},
+				Toughness:       4,
+				DefaultBlocking: true,
+				AbilityScript:   &Script{Receive: Resources{Attack: 3}},
+				Score:           Resources{Gold: 15, Red: 3},
+			},
+		},
+		{
+			"Pass: Barrier with numeric score",
+			testFile2,
+			Unit{
+				Name:            "Barrier",
+				UIName:          "Barrier",
+				Rarity:          "trinket",
+				BuyCost:         Resources{Gold: 1, Green: 1},
+				Toughness:       1,
+				Lifespan:        1,
+				DefaultBlocking: true,
+				Score:           Resources{},
+			},
+		},
+		{
+			"Pass: Engineer with fractional score",
+			testFile3,
+			Unit{
+				Name:               "Engineer",
+				BaseSet:            1,
+				Rarity:             "trinket",
+				BuyCost:            Resources{Gold: 2},
+				BuildTime:          1,
+				Toughness:          1,
+				DefaultBlocking:    true,
+				BeginOwnTurnScript: &Script{Receive: Resources{Energy: 1}},
+				Score:              Resources{Gold: 2.01},
+			},
+		},
+		{
+			"Pass: Drone takes a turn to build",
+			testFile1,
+			Unit{
+				Name:            "Drone",
+				BaseSet:         1,
+				Rarity:          "trinket",
+				BuyCost:         Resources{Gold: 3, Energy: 1},
+				BuildTime:       1,
+				Toughness:       1,
+				DefaultBlocking: true,
+				AbilityScript:   &Script{Receive: Resources{Gold: 1}},
+				Score:           Resources{},
+			},
+		},
+		{
+			"Pass: Wall is ready at once",
+			testFile1,
+			Unit{
+				Name:            "Wall",
+				BaseSet:         1,
+				Rarity:          "normal",
+				BuyCost:         Resources{Gold: 5, Blue: 1},
+				Toughness:       3,
+				DefaultBlocking: true,
+				Score:           Resources{Gold: 6},
+			},
+		},
+		{
+			"Pass: Rhino",
+			testFile3,
+			Unit{
+				Name:            "Rhino",
+				UIName:          "Rhino",
+				BaseSet:         1,
+				Rarity:          "normal",
+				BuyCost:         Resources{Gold: 5, Red: 1},
+				Toughness:       2,
+				Charge:          2,
+				DefaultBlocking: true,
+				AbilityScript:   &Script{Receive: Resources{Attack: 1}},
+				Score:           Resources{Gold: 4, Red: 1},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := openReplay(t, tt.file)
+
+			u := findUnit(t, &r.Deck, tt.exp.Name)
+			if !reflect.DeepEqual(u, tt.exp) {
+				t.Errorf("got: <%+v>, want: <%+v>", u, tt.exp)
+			}
+		})
+	}
+}
+
+func TestUnitBuildTimeJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  int
+		out  bool
+	}{
+		{"Pass: omitted", `{"name": "Drone"}`, 1, false},
+		{"Pass: instant", `{"name": "Wall", "buildTime": 0}`, 0, true},
+		{"Pass: slow", `{"name": "The Wincer", "buildTime": 3}`, 3, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var u Unit
+			if err := json.Unmarshal([]byte(tt.data), &u); err != nil {
+				t.Fatal(err)
+			}
+
+			if u.BuildTime != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", u.BuildTime, tt.exp)
+			}
+
+			b, err := json.Marshal(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if out := strings.Contains(string(b), `"buildTime"`); out != tt.out {
+				t.Errorf("got: <%s>, want: <buildTime written: %v>", b, tt.out)
+			}
+
+			var rt Unit
+			if err := json.Unmarshal(b, &rt); err != nil {
+				t.Fatal(err)
+			}
+
+			if rt.BuildTime != u.BuildTime {
+				t.Errorf("got: <%v>, want: <%v>", rt.BuildTime, u.BuildTime)
+			}
+		})
+	}
+}
+
+func TestUnitFlags(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  bool
+		fail bool
+	}{
+		{"Pass: replay number", `{"fragile": 1}`, true, false},
+		{"Pass: replay zero", `{"fragile": 0}`, false, false},
+		{"Pass: bool", `{"fragile": true}`, true, false},
+		{"Pass: missing", `{}`, false, false},
+		{"Error: string", `{"fragile": "yes"}`, false, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var u Unit
+			err := json.Unmarshal([]byte(tt.data), &u)
+			assertError(t, err, tt.fail)
+
+			if u.Fragile != tt.exp {
+				t.Errorf("got: <%v>, want: <%v>", u.Fragile, tt.exp)
+			}
+		})
+	}
+}
+
+func TestCreationJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  Creation
+		fail bool
+	}{
+		{"Pass: name and owner", `["Pixie", "own"]`, Creation{"Pixie", false, 1, -1}, false},
+		{"Pass: count", `["Gauss Charge", "own", 5]`, Creation{"Gauss Charge", false, 5, -1}, false},
+		{"Pass: build time", `["Gauss Charge", "opponent", 6, 3]`, Creation{"Gauss Charge", true, 6, 3}, false},
+		{"Error: missing owner", `["Pixie"]`, Creation{}, true},
+		{"Error: invalid owner", `["Pixie", "enemy"]`, Creation{}, true},
+		{"Error: invalid count", `["Pixie", "own", -2]`, Creation{}, true},
+		{"Error: not an array", `{"name": "Pixie"}`, Creation{}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Creation
+			err := json.Unmarshal([]byte(tt.data), &c)
+			assertError(t, err, tt.fail)
+
+			if tt.fail {
+				return
+			}
+
+			if c != tt.exp {
+				t.Errorf("got: <%+v>, want: <%+v>", c, tt.exp)
+			}
+
+			b, err := json.Marshal(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var rt Creation
+			if err := json.Unmarshal(b, &rt); err != nil {
+				t.Fatal(err)
+			}
+
+			if rt != c {
+				t.Errorf("got: <%+v>, want: <%+v>", rt, c)
+			}
+		})
+	}
+}
+
+func TestSacrificeJSON(t *testing.T) {
+	var cases = []struct {
+		name string
+		data string
+		exp  Sacrifice
+		fail bool
+	}{
+		{"Pass: name", `["Blastforge"]`, Sacrifice{"Blastforge", 1}, false},
+		{"Pass: count", `["Drone", 3]`, Sacrifice{"Drone", 3}, false},
+		{"Error: empty", `[]`, Sacrifice{}, true},
+		{"Error: invalid name", `[3]`, Sacrifice{}, true},
+		{"Error: fractional count", `["Drone", 1.5]`, Sacrifice{}, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Sacrifice
+			err := json.Unmarshal([]byte(tt.data), &s)
+			assertError(t, err, tt.fail)
+
+			if !tt.fail && s != tt.exp {
+				t.Errorf("got: <%+v>, want: <%+v>", s, tt.exp)
+			}
+		})
+	}
+}